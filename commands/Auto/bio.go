@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 	"zumygo/config"
 	"zumygo/libs"
@@ -14,6 +15,7 @@ import (
 var (
 	lastBioUpdate time.Time
 	bioTicker     *time.Ticker
+	bioMutex      sync.Mutex
 )
 
 // BioData holds dynamic data for bio template
@@ -28,24 +30,26 @@ type BioData struct {
 }
 
 func init() {
-	libs.NewCommands(&libs.ICommand{
-		Before: func(conn *libs.IClient, m *libs.IMessage) {
-			// Check if auto update bio is enabled
-			cfg := config.Config
-			if !cfg.AutoUpdateBio {
-				return
-			}
-
-			// Check if it's time to update bio (every BioInterval minutes)
-			now := time.Now()
-			if now.Sub(lastBioUpdate) < time.Duration(cfg.BioInterval)*time.Minute {
-				return
-			}
-
-			// Update bio
-			updateBio(conn, cfg)
-			lastBioUpdate = now
-		},
+	// Any incoming message gives the bio system a chance to refresh
+	libs.On(libs.EventMessage, func(conn *libs.IClient, evt *libs.IEvent) {
+		// Check if auto update bio is enabled
+		cfg := config.Config
+		if cfg == nil || !cfg.AutoUpdateBio {
+			return
+		}
+
+		// Check if it's time to update bio (every BioInterval minutes)
+		bioMutex.Lock()
+		now := time.Now()
+		if now.Sub(lastBioUpdate) < time.Duration(cfg.BioInterval)*time.Minute {
+			bioMutex.Unlock()
+			return
+		}
+		lastBioUpdate = now
+		bioMutex.Unlock()
+
+		// Update bio
+		updateBio(conn, cfg)
 	})
 }
 
@@ -58,7 +62,7 @@ func updateBio(conn *libs.IClient, cfg *config.BotConfig) {
 	bioText := processTemplate(cfg.BioTemplate, bioData)
 
 	// Update profile
-	err := conn.WA.SetStatusMessage(bioText)
+	err := conn.Messenger.SetStatusMessage(bioText)
 	if err != nil {
 		// Log error but don't panic
 		return
@@ -157,24 +161,24 @@ func GetGlobalBioSystem() *BioSystem {
 
 // SetClient sets the WhatsApp client for bio updates (not needed in this approach)
 func (bs *BioSystem) SetClient(client *whatsmeow.Client) {
-	// Not needed in Before hook approach
+	// Not needed in event listener approach
 }
 
 // Start starts the auto bio update system (not needed in this approach)
 func (bs *BioSystem) Start() error {
-	// Not needed in Before hook approach
+	// Not needed in event listener approach
 	return nil
 }
 
 // Stop stops the auto bio update system (not needed in this approach)
 func (bs *BioSystem) Stop() error {
-	// Not needed in Before hook approach
+	// Not needed in event listener approach
 	return nil
 }
 
 // IsRunning checks if the bio system is running (not needed in this approach)
 func (bs *BioSystem) IsRunning() bool {
-	// Not needed in Before hook approach
+	// Not needed in event listener approach
 	return true
 }
 
@@ -208,7 +212,7 @@ func (bs *BioSystem) ToggleAutoUpdate() bool {
 func (bs *BioSystem) GetStatus() map[string]interface{} {
 	return map[string]interface{}{
 		"enabled":  bs.cfg.AutoUpdateBio,
-		"running":  true, // Always running in Before hook approach
+		"running":  true, // Always running in event listener approach
 		"template": bs.cfg.BioTemplate,
 		"interval": bs.cfg.BioInterval,
 	}
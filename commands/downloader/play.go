@@ -1,6 +1,7 @@
 package downloader
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,16 +17,14 @@ func init() {
 		As:          []string{"play"},
 		Tags:        "downloader",
 		IsPrefix:    true,
-		IsQuery:     true,
-		Description: "Download YouTube videos as MP3 audio",
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
-			// Check if query is provided
-			if len(m.Args) == 0 {
-				m.Reply("Masukan URL atau judul lagu!\n\ncontoh:\n.play https://youtu.be/4rDOsvzTicY?si=3Ps-SJyRGzMa83QT\n.play despacito")
-				return false
-			}
-
-			query := strings.Join(m.Args, " ")
+		Description: "desc.play",
+		Args: []libs.IArg{
+			{Name: "query", Type: libs.ArgText, Required: true, Description: "arg.play.query"},
+		},
+		Cooldown: libs.ICooldown{User: 30 * time.Second},
+		Timeout:  2 * time.Minute,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			query := m.Params.String("query")
 
 			// Send processing reaction
 			m.React("⏱️")
@@ -34,7 +33,7 @@ func init() {
 			downloaderSystem := systems.EnsureGlobalDownloaderSystem(500 * time.Millisecond) // Reduced from 2s to 500ms
 			
 			if downloaderSystem == nil {
-				m.Reply("❎ Downloader system not available. Please try again.")
+				m.Reply(m.T("downloader.unavailable"))
 				return false
 			}
 
@@ -52,7 +51,7 @@ func init() {
 				
 				if videoInfo == nil {
 					// If not in cache, try to find the video in search results
-					searchResult, _ := downloaderSystem.SearchYouTubeByURL(query)
+					searchResult, _ := downloaderSystem.SearchYouTubeByURL(ctx, query)
 					if searchResult != nil {
 						videoInfo = &VideoInfo{
 							Title:     searchResult.Title,
@@ -68,7 +67,7 @@ func init() {
 				}
 				
 				// Download using the original URL
-				downloadResult, downloadErr = downloaderSystem.DownloadMedia("youtube", query)
+				downloadResult, downloadErr = downloaderSystem.DownloadMedia(ctx, "youtube", query)
 			} else {
 				// Text query - search for the song first, then download
 				// Check cache first using the query as key
@@ -76,9 +75,9 @@ func init() {
 				
 				if videoInfo == nil {
 					// If not in cache, search for the song
-					searchResult, searchErr := downloaderSystem.SearchYouTube(query)
+					searchResult, searchErr := downloaderSystem.SearchYouTube(ctx, query)
 					if searchErr != nil {
-						m.Reply(fmt.Sprintf("❎ Gagal mencari video: %v", searchErr))
+						m.Reply(m.T("play.search_failed", searchErr))
 						return false
 					}
 					
@@ -103,11 +102,11 @@ func init() {
 				}
 				
 				// Download the found video
-				downloadResult, downloadErr = downloaderSystem.DownloadMedia("youtube", videoInfo.URL)
+				downloadResult, downloadErr = downloaderSystem.DownloadMedia(ctx, "youtube", videoInfo.URL)
 			}
 
 			if downloadErr != nil {
-				m.Reply("❎ Terjadi kesalahan saat mengunduh audio!")
+				m.Reply(m.T("play.download_error"))
 				return false
 			}
 
@@ -116,12 +115,13 @@ func init() {
 				return false
 			}
 
-			// Download audio data
-			audioData, err := conn.GetBytes(downloadResult.URL)
+			// Download audio to a temporary file
+			audio, err := conn.FetchFile(ctx, downloadResult.URL)
 			if err != nil {
-				m.Reply("❎ Gagal mengunduh data audio")
+				m.Reply(m.T("play.fetch_failed"))
 				return false
 			}
+			defer audio.Cleanup()
 
 			// Create caption with detailed information from search results
 			var title, duration, views, author, published, videoId string
@@ -150,59 +150,54 @@ func init() {
 				title = downloadResult.Title
 				duration = downloadResult.Duration
 				views = downloadResult.Views
-				author = "Unknown"
-				published = "Unknown"
+				author = m.T("common.unknown")
+				published = m.T("common.unknown")
 				videoId = downloadResult.ID
 			}
 			
 			// Fallback untuk field yang masih kosong
+			unknown := m.T("common.unknown")
 			if title == "" {
-				title = "Unknown Title"
+				title = m.T("play.unknown_title")
 			}
 			if duration == "" {
-				duration = "Unknown"
+				duration = unknown
 			}
 			if views == "" {
-				views = "Unknown"
+				views = unknown
 			}
 			if author == "" {
-				author = "Unknown"
+				author = unknown
 			}
 			if published == "" {
-				published = "Unknown"
+				published = unknown
 			}
 			if videoId == "" {
-				videoId = "Unknown"
+				videoId = unknown
 			}
 			
-			caption := fmt.Sprintf(`*🎵 YT PLAY*
-
-◦ VideoID : %s
-◦ Title : %s
-◦ Duration : %s
-◦ Views : %s
-◦ Author : %s
-◦ Published : %s
-◦ URL : %s`, videoId, title, duration, views, author, published, 
+			caption := m.T("play.caption", videoId, title, duration, views, author, published, 
 				fmt.Sprintf("https://youtu.be/%s", videoId))
 
 			// Check if client is available
 			if conn == nil {
-				m.Reply("❎ Client not available for sending media")
+				m.Reply(m.T("common.no_client"))
 				return false
 			}
 
 			// Send audio file as document
-			_, err = conn.SendDocument(m.Info.Chat, audioData, fmt.Sprintf("%s.mp3", downloaderSystem.CleanFileName(title)), caption, nil)
+			_, err = conn.Message(m.Info.Chat).File(libs.MediaDocument, audio.Path).FileName(fmt.Sprintf("%s.mp3", downloaderSystem.CleanFileName(title))).Mimetype("audio/mpeg").Text(caption).Quote(m).Send(ctx)
 			if err != nil {
-				m.Reply("❎ Gagal mengirim audio document")
+				m.Reply(m.T("play.send_doc_failed"))
 				return false
 			}
 
-			// Also send as audio message
-			_, err = conn.SendAudio(m.Info.Chat, audioData, fmt.Sprintf("%s.mp3", downloaderSystem.CleanFileName(title)), nil)
+			// Also send as audio message, unless too large to play inline
+			if audio.Size <= libs.MediaLimits[libs.MediaAudio] {
+				_, err = conn.Message(m.Info.Chat).File(libs.MediaAudio, audio.Path).Quote(m).Send(ctx)
+			}
 			if err != nil {
-				m.Reply("❎ Gagal mengirim audio message")
+				m.Reply(m.T("play.send_audio_failed"))
 				return false
 			}
 
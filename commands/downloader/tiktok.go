@@ -1,7 +1,7 @@
 package downloader
 
 import (
-	"fmt"
+	"context"
 	"regexp"
 	"strings"
 	"sync"
@@ -16,20 +16,18 @@ func init() {
 		As:          []string{"tiktok"},
 		Tags:        "downloader",
 		IsPrefix:    true,
-		IsQuery:     true,
-		Description: "Download TikTok videos without watermark",
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
-			// Check if URL is provided
-			if len(m.Args) == 0 {
-				m.Reply("✳️ Masukkan tautan Tiktok\n\n 📌 Contoh : .tiktok https://vm.tiktok.com/ZMYG92bUh/")
-				return false
-			}
-
-			url := m.Args[0]
+		Description: "desc.tiktok",
+		Args: []libs.IArg{
+			{Name: "url", Type: libs.ArgURL, Required: true, Description: "arg.tiktok.url"},
+		},
+		Cooldown: libs.ICooldown{User: 30 * time.Second},
+		Timeout:  3 * time.Minute,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			url := m.Params.URL("url").String()
 
 			// Validate TikTok URL
 			if !strings.Contains(strings.ToLower(url), "tiktok") {
-				m.Reply("❎ Verifikasi bahwa tautan tersebut berasal dari TikTok")
+				m.Reply(m.T("tiktok.invalid"))
 				return false
 			}
 
@@ -40,7 +38,7 @@ func init() {
 			downloaderSystem := systems.EnsureGlobalDownloaderSystem(500 * time.Millisecond) // Reduced from 2s to 500ms
 			
 			if downloaderSystem == nil {
-				m.Reply("❎ Downloader system not available. Please try again.")
+				m.Reply(m.T("downloader.unavailable"))
 				return false
 			}
 
@@ -49,9 +47,9 @@ func init() {
 			tiktokInfo := getCachedTikTokInfo(tiktokID)
 			
 			// Download TikTok video
-			result, err := downloaderSystem.DownloadMedia("tiktok", url)
+			result, err := downloaderSystem.DownloadMedia(ctx, "tiktok", url)
 			if err != nil {
-				m.Reply("❎ Kesalahan mengunduh video")
+				m.Reply(m.T("tiktok.download_error"))
 				return false
 			}
 
@@ -74,47 +72,41 @@ func init() {
 			// Create caption with detailed information
 			title := result.Title
 			if title == "" {
-				title = "TikTok Video"
+				title = m.T("tiktok.default_title")
 			}
 			
-			caption := fmt.Sprintf(`┌─⊷ TIKTOK
-▢ *Deskripsi:* %s
-▢ *URL:* %s
-└───────────`, title, url)
+			caption := m.T("tiktok.caption", title, url)
 
 			// Check if client is available
 			if conn == nil {
-				m.Reply("❎ Client not available for sending media")
+				m.Reply(m.T("common.no_client"))
 				return false
 			}
 
 			// Handle slides (multiple images)
 			if result.IsSlide && len(result.URLs) > 1 {
-				m.Reply(fmt.Sprintf("📱 *TikTok Slide Detected*\n\n▢ *Total Images:* %d\n▢ *Title:* %s\n\n⏳ Sedang mengunduh dan mengirim slide...", len(result.URLs), title))
+				m.Reply(m.T("tiktok.slide_detected", len(result.URLs), title))
 				
 				// Send each image individually but with proper grouping
 				successCount := 0
 				for i, imageURL := range result.URLs {
 					// Download image data
-					imageData, err := conn.GetBytes(imageURL)
+					imageData, err := conn.GetBytes(ctx, imageURL)
 					if err != nil {
-						m.Reply(fmt.Sprintf("❎ Gagal mengunduh image %d/%d", i+1, len(result.URLs)))
+						m.Reply(m.T("tiktok.image_fetch_fail", i+1, len(result.URLs)))
 						continue
 					}
 					
 					// Only add caption to the first image
 					var slideCaption string
 					if i == 0 {
-						slideCaption = fmt.Sprintf(`┌─⊷ TIKTOK SLIDE
-▢ *Deskripsi:* %s
-▢ *URL:* %s
-└───────────`, title, url)
+						slideCaption = m.T("tiktok.slide_caption", title, url)
 					}
 					
 					// Send image
-					_, err = conn.SendImage(m.Info.Chat, imageData, slideCaption, nil)
+					_, err = conn.Message(m.Info.Chat).Image(imageData).Text(slideCaption).Quote(m).Send(ctx)
 					if err != nil {
-						m.Reply(fmt.Sprintf("❎ Gagal mengirim image %d/%d", i+1, len(result.URLs)))
+						m.Reply(m.T("tiktok.image_send_fail", i+1, len(result.URLs)))
 						continue
 					}
 					
@@ -122,26 +114,25 @@ func init() {
 					
 					// Small delay between sends to avoid rate limiting
 					if i < len(result.URLs)-1 {
-						time.Sleep(500 * time.Millisecond)
+						select {
+						case <-time.After(500 * time.Millisecond):
+						case <-ctx.Done():
+							return false
+						}
 					}
 				}
 				
 				if successCount > 0 {
-					m.Reply(fmt.Sprintf("✅ *TikTok Slide Berhasil*\n\n▢ *Total Images Sent:* %d/%d\n▢ *Title:* %s", successCount, len(result.URLs), title))
+					m.Reply(m.T("tiktok.slide_done", successCount, len(result.URLs), title))
 				}
 				
 				// Send audio file if available
 				if len(result.AudioURLs) > 0 {
-					audioData, err := conn.GetBytes(result.AudioURLs[0])
+					audioData, err := conn.GetBytes(ctx, result.AudioURLs[0])
 					if err == nil {
-						audioCaption := fmt.Sprintf(`┌─⊷ TIKTOK AUDIO
-▢ *Deskripsi:* %s
-▢ *URL:* %s
-└───────────`, title, url)
-						
-						_, err = conn.SendAudio(m.Info.Chat, audioData, audioCaption, nil)
+						_, err = conn.Message(m.Info.Chat).Audio(audioData).Quote(m).Send(ctx)
 						if err != nil {
-							m.Reply("❎ Gagal mengirim audio")
+							m.Reply(m.T("tiktok.audio_send_fail"))
 						}
 					}
 				}
@@ -152,32 +143,28 @@ func init() {
 					videoURL = result.URLs[0]
 				}
 				
-				// Download video data
-				videoData, err := conn.GetBytes(videoURL)
+				// Download video to a temporary file
+				video, err := conn.FetchFile(ctx, videoURL)
 				if err != nil {
-					m.Reply("❎ Gagal mengunduh data video")
+					m.Reply(m.T("tiktok.video_fetch_fail"))
 					return false
 				}
+				defer video.Cleanup()
 
 				// Send video file
-				_, err = conn.SendVideo(m.Info.Chat, videoData, caption, nil)
+				_, err = conn.Message(m.Info.Chat).File(libs.MediaVideo, video.Path).Text(caption).Quote(m).Send(ctx)
 				if err != nil {
-					m.Reply("❎ Gagal mengirim video")
+					m.Reply(m.T("tiktok.video_send_fail"))
 					return false
 				}
 				
 				// Send audio file if available
 				if len(result.AudioURLs) > 0 {
-					audioData, err := conn.GetBytes(result.AudioURLs[0])
+					audioData, err := conn.GetBytes(ctx, result.AudioURLs[0])
 					if err == nil {
-						audioCaption := fmt.Sprintf(`┌─⊷ TIKTOK AUDIO
-▢ *Deskripsi:* %s
-▢ *URL:* %s
-└───────────`, title, url)
-						
-						_, err = conn.SendAudio(m.Info.Chat, audioData, audioCaption, nil)
+						_, err = conn.Message(m.Info.Chat).Audio(audioData).Quote(m).Send(ctx)
 						if err != nil {
-							m.Reply("❎ Gagal mengirim audio")
+							m.Reply(m.T("tiktok.audio_send_fail"))
 						}
 					}
 				}
@@ -1,7 +1,7 @@
 package downloader
 
 import (
-	"fmt"
+	"context"
 	"strings"
 	"time"
 	"zumygo/libs"
@@ -16,16 +16,13 @@ func init() {
 		As:          []string{"ytsearch"},
 		Tags:        "downloader",
 		IsPrefix:    true,
-		IsQuery:     true,
-		Description: "Search YouTube videos",
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
-			// Check if query is provided
-			if len(m.Args) == 0 {
-				m.Reply("Masukan kata kunci pencarian!\n\ncontoh:\n.ytsearch despacito\n.ytsearch alex mica dalinda")
-				return false
-			}
-
-			query := strings.Join(m.Args, " ")
+		Description: "desc.ytsearch",
+		Args: []libs.IArg{
+			{Name: "query", Type: libs.ArgText, Required: true, Description: "arg.ytsearch.query"},
+		},
+		Cooldown: libs.ICooldown{User: 10 * time.Second},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			query := m.Params.String("query")
 
 			// Send processing reaction
 			m.React("⏱️")
@@ -34,20 +31,20 @@ func init() {
 			downloaderSystem := systems.EnsureGlobalDownloaderSystem(500 * time.Millisecond) // Reduced from 2s to 500ms
 			
 			if downloaderSystem == nil {
-				m.Reply("❎ Downloader system not available. Please try again.")
+				m.Reply(m.T("downloader.unavailable"))
 				return false
 			}
 
 			// Search for videos using the downloader system
-			searchResults, err := downloaderSystem.SearchYouTubeMultiple(query)
+			searchResults, err := downloaderSystem.SearchYouTubeMultiple(ctx, query)
 			if err != nil {
-				m.Reply(fmt.Sprintf("❎ Gagal melakukan pencarian: %v", err))
+				m.Reply(m.T("ytsearch.failed", err))
 				return false
 			}
 
 			// Create search results message
 			var results []string
-			results = append(results, fmt.Sprintf("*🔍 YouTube Search Results*\n*Query:* %s\n", query))
+			results = append(results, m.T("ytsearch.header", query))
 
 			// Limit to first 10 results
 			maxResults := 10
@@ -55,41 +52,45 @@ func init() {
 				maxResults = len(searchResults)
 			}
 
+			var urls []string
+			var rows []libs.IListRow
 			for i := 0; i < maxResults; i++ {
 				result := searchResults[i]
+				urls = append(urls, result.URL)
 				
 				// Format duration and views
 				duration := result.Duration
 				if duration == "" {
-					duration = "Unknown"
+					duration = m.T("common.unknown")
 				}
 				
 				views := downloaderSystem.FormatViews(result.Views)
 				if result.Views == 0 {
-					views = "Unknown"
+					views = m.T("common.unknown")
 				}
 
 				// Create result entry
-				entry := fmt.Sprintf(`*%d.* %s
-⏱️ *Duration:* %s
-👁️ *Views:* %s
-📅 *Published:* %s
-👤 *Author:* %s
-🔗 *URL:* %s
-
-`, i+1, result.Title, duration, views, result.Published, result.Author, result.URL)
+				entry := m.T("ytsearch.entry", i+1, result.Title, duration, views, result.Published, result.Author, result.URL)
 				
 				results = append(results, entry)
+				rows = append(rows, libs.IListRow{
+					ID:          "play " + result.URL,
+					Title:       result.Title,
+					Description: result.Author + " • " + duration,
+				})
 			}
 
 			// Join all results
 			fullMessage := strings.Join(results, "")
 
 			// Add footer
-			fullMessage += fmt.Sprintf("\n*Total Results:* %d\n*Use .play <URL> to download audio*", len(searchResults))
+			fullMessage += m.T("ytsearch.footer", len(searchResults))
 
-			// Send the search results
-			m.Reply(fullMessage)
+			// Send the search results and remember them for numbered replies
+			resp, err := m.ReplySelect(fullMessage, m.T("ytsearch.select"), rows)
+			if err == nil {
+				libs.RememberResults(resp.ID, m.Info.Chat, "play", urls)
+			}
 
 			// Send success reaction
 			m.React("✅")
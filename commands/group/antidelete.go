@@ -0,0 +1,46 @@
+package group
+
+import (
+	"context"
+	"zumygo/database"
+	"zumygo/libs"
+)
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "(antidelete|antidel)",
+		As:          []string{"antidelete"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.antidelete",
+		Permission:  libs.PermGroupAdmin,
+		Args: []libs.IArg{
+			{Name: "state", Type: libs.ArgEnum, Choices: []string{"on", "off"}, Description: "arg.antidelete.state"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if database.DB == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			chatID := m.Info.Chat.String()
+			switch m.Params.String("state") {
+			case "on":
+				database.DB.SetChatDelete(chatID, true)
+				m.Reply(m.T("antidelete.on"))
+
+			case "off":
+				database.DB.SetChatDelete(chatID, false)
+				m.Reply(m.T("antidelete.off"))
+
+			default:
+				state := m.T("antidelete.state_off")
+				if database.DB.GetChatDelete(chatID) {
+					state = m.T("antidelete.state_on")
+				}
+				m.Reply(m.T("antidelete.current", state, libs.Usage(m.Cmd, m.Prefix, m.Lang())))
+			}
+			return true
+		},
+	})
+}
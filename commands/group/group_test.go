@@ -0,0 +1,191 @@
+package group
+
+import (
+	"testing"
+	"zumygo/database"
+	"zumygo/locales"
+	"zumygo/testkit"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+var testGroup = types.NewJID("120363000000000001", types.GroupServer)
+
+func TestPrefixRequiresAdmin(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, nil, h.User)
+
+	h.Send(testGroup, h.User, ".prefix set !")
+	if reply, want := h.LastReply(), locales.T(locales.ID, "perm.admin"); reply != want {
+		t.Errorf("reply = %q, want %q", reply, want)
+	}
+}
+
+func TestPrefixOverride(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	h.Send(testGroup, h.User, ".prefix set ! >>")
+	if reply, want := h.LastReply(), locales.T(locales.ID, "prefix.set", "! >>"); reply != want {
+		t.Fatalf("reply = %q, want %q", reply, want)
+	}
+
+	if m := h.Send(testGroup, h.User, ".disabled"); m.Cmd != nil {
+		t.Errorf("old prefix still runs commands")
+	}
+	for _, body := range []string{"!disabled", ">>disabled", "@" + testkit.BotJID.User + " disabled"} {
+		if m := h.Send(testGroup, h.User, body); m.Cmd == nil {
+			t.Errorf("%q did not run a command", body)
+		}
+	}
+
+	// Other chats keep the global prefixes
+	if m := h.Private(h.User, ".disabled"); m.Cmd == nil {
+		t.Errorf("override leaked into another chat")
+	}
+}
+
+func TestPrefixKeepsCase(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	h.Send(testGroup, h.User, ".prefix set Z!")
+	if m := h.Send(testGroup, h.User, "Z!DISABLED"); m.Cmd == nil || m.Prefix != "Z!" {
+		t.Errorf("Z! did not run a command")
+	}
+	if m := h.Send(testGroup, h.User, "z!disabled"); m.Cmd != nil {
+		t.Errorf("prefix matched with a different case")
+	}
+}
+
+func TestNoPrefixMode(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	if m := h.Send(testGroup, h.User, "disabled"); m.Cmd != nil {
+		t.Fatalf("command ran without a prefix before no-prefix mode")
+	}
+
+	h.Send(testGroup, h.User, ".prefix none")
+	if m := h.Send(testGroup, h.User, "disabled"); m.Cmd == nil {
+		t.Errorf("command did not run in no-prefix mode")
+	}
+	if m := h.Send(testGroup, h.User, "hello disabled"); m.Cmd != nil {
+		t.Errorf("plain text ran a command in no-prefix mode")
+	}
+}
+
+func TestDisableCommand(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	h.Send(testGroup, h.User, ".disable suggest")
+	if m := h.Send(testGroup, h.User, ".suggest off"); m.Cmd != nil {
+		t.Errorf("disabled command still resolved")
+	}
+
+	h.Send(testGroup, h.User, ".enable suggest")
+	if m := h.Send(testGroup, h.User, ".suggest off"); m.Cmd == nil {
+		t.Errorf("enabled command did not resolve")
+	}
+}
+
+func TestEnableInChatKeepsGlobalDisable(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User, h.Owner})
+
+	h.Send(testGroup, h.Owner, ".disable suggest --global")
+	h.Send(testGroup, h.User, ".enable suggest")
+	if reply, want := h.LastReply(), locales.T(locales.ID, "toggle.global_off", "suggest"); reply != want {
+		t.Errorf("reply = %q, want %q", reply, want)
+	}
+	if m := h.Send(testGroup, h.User, ".suggest off"); m.Cmd != nil {
+		t.Errorf("globally disabled command ran after a chat enable")
+	}
+
+	h.Send(testGroup, h.Owner, ".enable suggest --global")
+	if m := h.Send(testGroup, h.User, ".suggest off"); m.Cmd == nil {
+		t.Errorf("command did not run once enabled globally")
+	}
+}
+
+func TestAntiDelete(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	revoke := func(id types.MessageID) {
+		h.Event(testkit.NewMessage(testGroup, h.User, &waE2E.Message{
+			ProtocolMessage: &waE2E.ProtocolMessage{
+				Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+				Key:  &waCommon.MessageKey{ID: proto.String(id)},
+			},
+		}))
+	}
+
+	m := h.Send(testGroup, h.User, "secret plan")
+	h.Fake.Reset()
+	revoke(m.Info.ID)
+	want := locales.T(locales.ID, "antidelete.notice", h.User.User) + "\n\nsecret plan"
+	if reply := h.LastReply(); reply != want {
+		t.Errorf("repost = %q, want %q", reply, want)
+	}
+
+	// View-once media must not come back as a permanent copy
+	m = h.Event(testkit.NewMessage(testGroup, h.User, &waE2E.Message{
+		ViewOnceMessageV2: &waE2E.FutureProofMessage{Message: &waE2E.Message{
+			ImageMessage: &waE2E.ImageMessage{DirectPath: proto.String("/fake/once"), ViewOnce: proto.Bool(true)},
+		}},
+	}))
+	h.Fake.Reset()
+	revoke(m.Info.ID)
+	if sent := h.Fake.Sent(); len(sent) != 0 {
+		t.Errorf("reposted view-once media: %v", sent)
+	}
+
+	h.Send(testGroup, h.User, ".antidelete off")
+	m = h.Send(testGroup, h.User, "another secret")
+	h.Fake.Reset()
+	revoke(m.Info.ID)
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("reposted with anti-delete off: %q", replies)
+	}
+}
+
+func TestAntiDeleteOffInPrivate(t *testing.T) {
+	h := testkit.New(t)
+
+	m := h.Private(h.User, "private secret")
+	h.Fake.Reset()
+	h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ProtocolMessage: &waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+			Key:  &waCommon.MessageKey{ID: proto.String(m.Info.ID)},
+		},
+	}))
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("reposted in a private chat by default: %q", replies)
+	}
+}
+
+func TestSuggestToggle(t *testing.T) {
+	h := testkit.New(t)
+	h.AddGroup(testGroup, []types.JID{h.User})
+
+	h.Send(testGroup, h.User, ".suggest off")
+	if !database.DB.GetChat(testGroup.String()).NoSuggest {
+		t.Fatalf("suggestions still on after .suggest off")
+	}
+	h.Fake.Reset()
+	if h.Send(testGroup, h.User, ".antidelet"); len(h.Replies()) != 0 {
+		t.Errorf("suggested %q with suggestions off", h.Replies())
+	}
+
+	h.Send(testGroup, h.User, ".suggest on")
+	h.Fake.Reset()
+	if h.Send(testGroup, h.User, ".antidelet"); len(h.Replies()) != 1 {
+		t.Errorf("replies = %q, want a suggestion", h.Replies())
+	}
+}
@@ -0,0 +1,74 @@
+package group
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+	"zumygo/database"
+	"zumygo/libs"
+)
+
+// maxPrefixLength bounds a single custom prefix, in characters
+const maxPrefixLength = 5
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "prefix",
+		As:          []string{"prefix"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.prefix",
+		Permission:  libs.PermGroupAdmin,
+		NoDisable:   true,
+		Args: []libs.IArg{
+			{Name: "action", Type: libs.ArgEnum, Choices: []string{"set", "reset", "none"}, Description: "arg.prefix.action"},
+			{Name: "value", Type: libs.ArgText, Description: "arg.prefix.value"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if database.DB == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			chatID := m.Info.Chat.String()
+			switch m.Params.String("action") {
+			case "set":
+				prefixes := strings.Fields(m.Params.String("value"))
+				if len(prefixes) == 0 {
+					m.Reply(libs.Usage(m.Cmd, m.Prefix, m.Lang()))
+					return false
+				}
+				for _, prefix := range prefixes {
+					if utf8.RuneCountInString(prefix) > maxPrefixLength {
+						m.Reply(m.T("prefix.too_long", prefix, maxPrefixLength))
+						return false
+					}
+				}
+				database.DB.SetChatPrefixes(chatID, prefixes)
+				m.Reply(m.T("prefix.set", strings.Join(prefixes, " ")))
+
+			case "reset":
+				database.DB.SetChatPrefixes(chatID, nil)
+				database.DB.SetChatNoPrefix(chatID, false)
+				m.Reply(m.T("prefix.reset", strings.Join(libs.GetPrefixes(), " ")))
+
+			case "none":
+				_, noPrefix := database.DB.GetChatPrefixes(chatID)
+				database.DB.SetChatNoPrefix(chatID, !noPrefix)
+				if noPrefix {
+					m.Reply(m.T("prefix.none_off"))
+				} else {
+					m.Reply(m.T("prefix.none_on"))
+				}
+
+			default:
+				state := m.T("prefix.state_off")
+				if libs.IsNoPrefixChat(chatID) {
+					state = m.T("prefix.state_on")
+				}
+				m.Reply(m.T("prefix.current", strings.Join(libs.GetChatPrefixes(chatID), " "), state, libs.Usage(m.Cmd, m.Prefix, m.Lang())))
+			}
+			return true
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package group
+
+import (
+	"context"
+	"zumygo/database"
+	"zumygo/libs"
+)
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "suggest",
+		As:          []string{"suggest"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.suggest",
+		Permission:  libs.PermGroupAdmin,
+		Args: []libs.IArg{
+			{Name: "state", Type: libs.ArgEnum, Required: true, Choices: []string{"on", "off"}, Description: "arg.suggest.state"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if database.DB == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			enabled := m.Params.String("state") == "on"
+			database.DB.SetChatSuggest(m.Info.Chat.String(), enabled)
+			if enabled {
+				m.Reply(m.T("suggest.on"))
+			} else {
+				m.Reply(m.T("suggest.off"))
+			}
+			return true
+		},
+	})
+}
@@ -0,0 +1,137 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"zumygo/database"
+	"zumygo/libs"
+)
+
+func init() {
+	toggleArgs := []libs.IArg{
+		{Name: "target", Type: libs.ArgString, Required: true, Description: "arg.toggle.target"},
+		{Name: "global", Type: libs.ArgFlag, Description: "arg.toggle.global"},
+	}
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "disable",
+		As:          []string{"disable"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.disable",
+		Permission:  libs.PermGroupAdmin,
+		NoDisable:   true,
+		Args:        toggleArgs,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			return toggleCommand(m, true)
+		},
+	})
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "enable",
+		As:          []string{"enable"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.enable",
+		Permission:  libs.PermGroupAdmin,
+		NoDisable:   true,
+		Args:        toggleArgs,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			return toggleCommand(m, false)
+		},
+	})
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "disabled",
+		As:          []string{"disabled"},
+		Tags:        "group",
+		IsPrefix:    true,
+		Description: "desc.disabled",
+		Permission:  libs.PermGroupAdmin,
+		NoDisable:   true,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if database.DB == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			var str strings.Builder
+			str.WriteString(m.T("toggle.list_title"))
+			str.WriteString(formatKeys(m, m.T("toggle.list_global"), database.DB.GetDisabledCommands("")))
+			str.WriteString("\n")
+			str.WriteString(formatKeys(m, m.T("toggle.list_chat"), database.DB.GetDisabledCommands(m.Info.Chat.String())))
+
+			m.Reply(str.String())
+			return true
+		},
+	})
+}
+
+// toggleCommand disables or enables the requested target for the chat or globally
+func toggleCommand(m *libs.IMessage, disable bool) bool {
+	if database.DB == nil {
+		m.Reply(m.T("common.db_unavailable"))
+		return false
+	}
+
+	global := m.Params.Flag("global")
+	if global && !m.IsOwner {
+		m.Reply(m.T(libs.DenyOwner))
+		return false
+	}
+
+	target := m.Params.String("target")
+	key, ok := libs.ResolveToggleTarget(target)
+	if !ok {
+		m.Reply(m.T("toggle.not_found", target))
+		return false
+	}
+
+	if cmd, found := libs.Resolve(target); found && cmd.NoDisable {
+		m.Reply(m.T("toggle.locked", key))
+		return false
+	}
+
+	chatID := m.Info.Chat.String()
+	scope := m.T("toggle.scope_chat")
+	if global {
+		chatID = ""
+		scope = m.T("toggle.scope_global")
+	}
+
+	action := m.T("toggle.enabled")
+	if disable {
+		action = m.T("toggle.disabled")
+	}
+
+	changed := database.DB.SetCommandDisabled(chatID, key, disable)
+
+	// A chat cannot turn back on what the owner turned off everywhere
+	if !disable && !global && libs.IsDisabledGlobally(key) {
+		m.Reply(m.T("toggle.global_off", key))
+		return true
+	}
+
+	if !changed {
+		m.Reply(m.T("toggle.already", key, action, scope))
+		return true
+	}
+
+	m.Reply(m.T("toggle.done", key, action, scope))
+	return true
+}
+
+// formatKeys renders one scope of disabled keys
+func formatKeys(m *libs.IMessage, title string, keys []string) string {
+	if len(keys) == 0 {
+		return m.T("toggle.list_none", title)
+	}
+
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("*%s:*\n", title))
+	for _, key := range keys {
+		str.WriteString(fmt.Sprintf("• %s\n", key))
+	}
+	return str.String()
+}
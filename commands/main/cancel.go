@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+	"zumygo/libs"
+)
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "(cancel|stop)",
+		As:          []string{"cancel"},
+		Tags:        "main",
+		IsPrefix:    true,
+		Description: "desc.cancel",
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			count := libs.CancelCommands(m)
+			if count == 0 {
+				m.Reply(m.T("cancel.none"))
+				return false
+			}
+
+			m.Reply(m.T("cancel.done", count))
+			return true
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"zumygo/database"
+	"zumygo/libs"
+	"zumygo/locales"
+)
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "(language|lang|bahasa)",
+		As:          []string{"language"},
+		Tags:        "main",
+		IsPrefix:    true,
+		Description: "desc.language",
+		Args: []libs.IArg{
+			{Name: "code", Type: libs.ArgString, Description: "arg.language"},
+			{Name: "chat", Type: libs.ArgFlag, Description: "arg.language.chat"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if database.DB == nil || m.User == nil || m.ChatData == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			available := strings.Join(locales.Supported(), ", ")
+			if !m.Params.Has("code") {
+				userLang, chatLang := m.User.Language, m.ChatData.Language
+				if userLang == "" {
+					userLang = m.T("language.unset")
+				}
+				if chatLang == "" {
+					chatLang = m.T("language.unset")
+				}
+				m.Reply(m.T("language.current", userLang, chatLang, available, libs.Usage(m.Cmd, m.Prefix, m.Lang())))
+				return true
+			}
+
+			code := strings.ToLower(m.Params.String("code"))
+			if !locales.IsSupported(code) {
+				m.Reply(m.T("language.invalid", code, available))
+				return false
+			}
+
+			if m.Params.Flag("chat") {
+				// Changing the chat language needs group admin rights
+				admin := &libs.ICommand{Permission: libs.PermGroupAdmin}
+				if denial, ok := libs.CheckPermission(conn, admin, m); !ok {
+					m.Reply(m.T(denial))
+					return false
+				}
+
+				database.DB.SetChatLanguage(m.Info.Chat.String(), code)
+				m.Reply(m.T("language.chat", code))
+				return true
+			}
+
+			database.DB.SetUserLanguage(m.Sender.ToNonAD().String(), code)
+			m.Reply(m.T("language.user", code))
+			return true
+		},
+	})
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"zumygo/config"
+	"zumygo/database"
+	"zumygo/locales"
+	"zumygo/testkit"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPingReplies(t *testing.T) {
+	h := testkit.New(t)
+
+	m := h.Private(h.User, ".ping")
+	if m.Cmd == nil || m.Command != "ping" {
+		t.Fatalf("command = %q, want ping resolved", m.Command)
+	}
+	if reply := h.LastReply(); !strings.HasPrefix(reply, "*Ping :*") {
+		t.Errorf("reply = %q, want ping result", reply)
+	}
+}
+
+func TestPlainTextIsIgnored(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, "ping")
+	h.Private(h.User, "hello there")
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("replies = %q, want none", replies)
+	}
+}
+
+func TestPrivateModeIgnoresOthers(t *testing.T) {
+	h := testkit.New(t)
+	config.Config.PublicMode = false
+
+	h.Private(h.User, ".ping")
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("replies to user = %q, want none", replies)
+	}
+
+	h.Private(h.Owner, ".ping")
+	if replies := h.Replies(); len(replies) != 1 {
+		t.Errorf("replies to owner = %q, want one", replies)
+	}
+}
+
+func TestOwnerNeedsWholeNumber(t *testing.T) {
+	h := testkit.New(t)
+	config.Config.PublicMode = false
+
+	// A prefix of the owner number must not pass as the owner
+	partial := types.NewJID(h.Owner.User[:8], types.DefaultUserServer)
+	if m := h.Private(partial, ".ping"); m.IsOwner {
+		t.Errorf("%s treated as owner %s", partial.User, h.Owner.User)
+	}
+
+	config.Config.Owner = []string{"+" + h.Owner.User[:2] + " " + h.Owner.User[2:]}
+	device := h.Owner
+	device.Device = 12
+	if m := h.Private(device, ".ping"); !m.IsOwner {
+		t.Errorf("formatted owner number not matched for a device JID")
+	}
+}
+
+func TestTypoSuggestsCommand(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, ".pingg")
+	want := locales.T(locales.ID, "cmd.suggest", ".", "ping")
+	if reply := h.LastReply(); reply != want {
+		t.Errorf("reply = %q, want %q", reply, want)
+	}
+}
+
+func TestPrivateModeSuggestsOnlyToOwner(t *testing.T) {
+	h := testkit.New(t)
+	config.Config.PublicMode = false
+
+	h.Private(h.User, ".pingg")
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("replies to user = %q, want none", replies)
+	}
+
+	h.Private(h.Owner, ".pingg")
+	want := locales.T(locales.ID, "cmd.suggest", ".", "ping")
+	if reply := h.LastReply(); reply != want {
+		t.Errorf("reply to owner = %q, want %q", reply, want)
+	}
+}
+
+func TestMenuIsTapDriven(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, ".menu")
+	sent := h.Fake.Sent()
+	flow := sent[len(sent)-1].Message.GetViewOnceMessage().GetMessage().GetInteractiveMessage().GetNativeFlowMessage()
+	if flow == nil || len(flow.GetButtons()) != 1 || !strings.Contains(flow.GetButtons()[0].GetButtonParamsJSON(), `"id":"menu main"`) {
+		t.Fatalf("menu = %v, want a select button opening the main category", flow)
+	}
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		InteractiveResponseMessage: &waE2E.InteractiveResponseMessage{
+			InteractiveResponseMessage: &waE2E.InteractiveResponseMessage_NativeFlowResponseMessage_{
+				NativeFlowResponseMessage: &waE2E.InteractiveResponseMessage_NativeFlowResponseMessage{
+					Name:       proto.String("single_select"),
+					ParamsJSON: proto.String(`{"id":"menu main"}`),
+				},
+			},
+		},
+	}))
+	if m.Cmd == nil || m.Command != "menu" || m.Text != "main" {
+		t.Errorf("selection ran %q with %q, want menu main", m.Command, m.Text)
+	}
+
+	config.Config.Interactive = false
+	h.Private(h.User, ".menu")
+	sent = h.Fake.Sent()
+	if sent[len(sent)-1].Message.GetViewOnceMessage() != nil {
+		t.Errorf("interactive menu sent with interactive messages off")
+	}
+}
+
+func TestButtonResponseRunsCommand(t *testing.T) {
+	h := testkit.New(t)
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ButtonsResponseMessage: &waE2E.ButtonsResponseMessage{SelectedButtonID: proto.String("ping")},
+	}))
+	if m.Cmd == nil || m.Command != "ping" || m.Prefix != "." {
+		t.Errorf("button ran %q with prefix %q, want ping", m.Command, m.Prefix)
+	}
+
+	m = h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ListResponseMessage: &waE2E.ListResponseMessage{
+			SingleSelectReply: &waE2E.ListResponseMessage_SingleSelectReply{SelectedRowID: proto.String("hello")},
+		},
+	}))
+	if m.Cmd != nil {
+		t.Errorf("row without a command ran %q", m.Command)
+	}
+}
+
+func TestDocumentCaptionRunsCommand(t *testing.T) {
+	h := testkit.New(t)
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		DocumentWithCaptionMessage: &waE2E.FutureProofMessage{
+			Message: &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+				Caption:    proto.String(".ping"),
+				DirectPath: proto.String("/fake/document/1"),
+			}},
+		},
+	}))
+	if m.Command != "ping" || m.Kind != "document" {
+		t.Fatalf("command = %q kind = %q, want ping from a document", m.Command, m.Kind)
+	}
+	if m.Media == nil {
+		t.Errorf("Media = nil, want the captioned document")
+	}
+	if reply := h.LastReply(); !strings.HasPrefix(reply, "*Ping :*") {
+		t.Errorf("reply = %q, want ping result", reply)
+	}
+}
+
+func TestOnlyTextAndCaptionsRunCommands(t *testing.T) {
+	h := testkit.New(t)
+
+	for kind, msg := range map[string]*waE2E.Message{
+		"poll":     {PollCreationMessage: &waE2E.PollCreationMessage{Name: proto.String(".ping")}},
+		"contact":  {ContactMessage: &waE2E.ContactMessage{DisplayName: proto.String(".ping")}},
+		"location": {LocationMessage: &waE2E.LocationMessage{Name: proto.String(".ping")}},
+		"event":    {EventMessage: &waE2E.EventMessage{Name: proto.String(".ping")}},
+	} {
+		h.Fake.Reset()
+		m := h.Event(testkit.NewMessage(h.User, h.User, msg))
+		if m.Command != "" || len(h.Replies()) != 0 {
+			t.Errorf("%s ran command %q", kind, m.Command)
+		}
+		if m.Kind != kind || m.Body == "" {
+			t.Errorf("%s: kind = %q body = %q, want its text kept", kind, m.Kind, m.Body)
+		}
+	}
+}
+
+func TestLanguageIsStored(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, ".language en")
+	if got := database.DB.GetUser(h.User.String()).Language; got != "en" {
+		t.Errorf("user language = %q, want en", got)
+	}
+
+	h.Private(h.Owner, ".language en --chat")
+	if got := database.DB.GetChat(h.Owner.String()).Language; got != "en" {
+		t.Errorf("chat language = %q, want en", got)
+	}
+}
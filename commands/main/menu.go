@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -33,27 +34,26 @@ func createCategoryMenu(conn *libs.IClient, m *libs.IMessage, category string) b
 	var str strings.Builder
 	
 	// Professional header
-	str.WriteString("🎯 *ZUMYGO BOT MENU*\n")
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString(fmt.Sprintf("👤 User: %s\n", m.Info.PushName))
-	str.WriteString(fmt.Sprintf("📱 Category: %s\n", strings.ToUpper(category)))
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+	str.WriteString(m.T("menu.title"))
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.user", m.Info.PushName))
+	str.WriteString(m.T("menu.category", strings.ToUpper(category)))
+	str.WriteString(m.T("menu.divider") + "\n")
 	
 	// Get commands for the specific category
 	var commands []item
-	for _, list := range libs.GetList() {
+	for _, list := range visibleCommands(m) {
 		if strings.ToLower(list.Tags) == strings.ToLower(category) {
 			commands = append(commands, item{
 				Name:        list.As,
 				IsPrefix:    list.IsPrefix,
-				Description: list.Description,
+				Description: m.T(list.Description),
 			})
 		}
 	}
 	
 	if len(commands) == 0 {
-		str.WriteString("❌ No commands found for this category.\n")
-		str.WriteString("💡 Try: .menu to see all categories\n")
+		str.WriteString(m.T("menu.no_commands"))
 		m.Reply(str.String())
 		return true
 	}
@@ -63,13 +63,13 @@ func createCategoryMenu(conn *libs.IClient, m *libs.IMessage, category string) b
 	for _, cmd := range commands {
 		var prefix string
 		if cmd.IsPrefix {
-			prefix, _ = libs.ExtractPrefix(m.Body)
+			prefix = m.Prefix
 		}
 		
 		for _, name := range cmd.Name {
 			description := cmd.Description
 			if description == "" {
-				description = "No description available"
+				description = m.T("menu.no_description")
 			}
 			
 			str.WriteString(fmt.Sprintf("%d. *%s%s*\n", counter, prefix, name))
@@ -79,23 +79,30 @@ func createCategoryMenu(conn *libs.IClient, m *libs.IMessage, category string) b
 	}
 	
 	// Footer with navigation
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString("💡 *Navigation:*\n")
-	str.WriteString("• .menu - Show main menu\n")
-	str.WriteString("• .menu [category] - Show specific category\n")
-	str.WriteString("• .help - Show detailed help\n")
-	str.WriteString("• Example: .menu downloader\n\n")
-	str.WriteString("🔧 Powered by ZUMYGO Bot")
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.navigation"))
+	str.WriteString(m.T("menu.powered"))
 	
 	m.Reply(str.String())
 	return true
 }
 
+// visibleCommands returns the commands not disabled for the message chat
+func visibleCommands(m *libs.IMessage) []libs.ICommand {
+	var result []libs.ICommand
+	for _, list := range libs.GetList() {
+		if !libs.IsCommandDisabled(&list, m.Info.Chat.String()) {
+			result = append(result, list)
+		}
+	}
+	return result
+}
+
 // getAvailableCategories returns all available command categories
-func getAvailableCategories() []string {
+func getAvailableCategories(m *libs.IMessage) []string {
 	categories := make(map[string]bool)
 	
-	for _, list := range libs.GetList() {
+	for _, list := range visibleCommands(m) {
 		if list.Tags != "" {
 			categories[list.Tags] = true
 		}
@@ -116,6 +123,7 @@ func getCategoryDisplayName(category string) string {
 		"main":       "🏠 Main",
 		"downloader": "📥 Download",
 		"owner":      "⚙️ Owner",
+		"group":      "👥 Group",
 		"auto":       "🤖 Auto",
 		"tools":      "🛠️ Tools",
 		"fun":        "🎮 Fun",
@@ -130,9 +138,9 @@ func getCategoryDisplayName(category string) string {
 }
 
 // getCommandCount returns the number of commands in a category
-func getCommandCount(category string) int {
+func getCommandCount(m *libs.IMessage, category string) int {
 	count := 0
-	for _, list := range libs.GetList() {
+	for _, list := range visibleCommands(m) {
 		if strings.ToLower(list.Tags) == strings.ToLower(category) {
 			count += len(list.As)
 		}
@@ -145,40 +153,45 @@ func mainMenu(conn *libs.IClient, m *libs.IMessage) bool {
 	var str strings.Builder
 	
 	// Professional header with emojis and styling
-	str.WriteString("🎯 *ZUMYGO BOT - MAIN MENU*\n")
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString(fmt.Sprintf("👤 Welcome, %s!\n", m.Info.PushName))
-	str.WriteString("🤖 Your AI-powered WhatsApp assistant\n")
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+	str.WriteString(m.T("menu.main_title"))
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.welcome", m.Info.PushName))
+	str.WriteString(m.T("menu.tagline"))
+	str.WriteString(m.T("menu.divider") + "\n")
 	
 	// Get all categories
-	categories := getAvailableCategories()
+	categories := getAvailableCategories(m)
 	
 	// Display category overview with command counts
-	str.WriteString("*📋 Available Categories:*\n\n")
+	str.WriteString(m.T("menu.categories"))
 	
 	counter := 1
+	var rows []libs.IListRow
 	for _, category := range categories {
 		displayName := getCategoryDisplayName(category)
-		commandCount := getCommandCount(category)
+		commandCount := getCommandCount(m, category)
 		
 		str.WriteString(fmt.Sprintf("%d. %s\n", counter, displayName))
-		str.WriteString(fmt.Sprintf("   └ %d commands available\n\n", commandCount))
+		str.WriteString(m.T("menu.category_count", commandCount))
+		rows = append(rows, libs.IListRow{
+			ID:          "menu " + strings.ToLower(category),
+			Title:       displayName,
+			Description: m.T("menu.row_count", commandCount),
+		})
 		counter++
 	}
 	
 	// Footer with instructions and status
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString("💡 *How to use:*\n")
-	str.WriteString("• .menu [category] - Explore specific category\n")
-	str.WriteString("• .help - Show detailed help\n")
-	str.WriteString("• Example: .menu downloader\n")
-	str.WriteString("• Example: .menu owner\n\n")
-	str.WriteString("🔧 *Bot Status:* Online ✅\n")
-	str.WriteString("📊 *Version:* 2.0 Professional\n")
-	str.WriteString("🌟 *Features:* Interactive & Fast")
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.how_to"))
+	str.WriteString(m.T("menu.status"))
 	
-	m.Reply(str.String())
+	// Remember the numbered categories so a reply can open one, besides
+	// tapping it in the list
+	resp, err := m.ReplySelect(str.String(), m.T("menu.select"), rows)
+	if err == nil {
+		libs.RememberResults(resp.ID, m.Info.Chat, "menu", categories)
+	}
 	return true
 }
 
@@ -187,50 +200,36 @@ func helpMenu(conn *libs.IClient, m *libs.IMessage) bool {
 	var str strings.Builder
 	
 	// Professional header
-	str.WriteString("📚 *ZUMYGO BOT - HELP & GUIDE*\n")
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString(fmt.Sprintf("👤 User: %s\n", m.Info.PushName))
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+	str.WriteString(m.T("menu.help_title"))
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.user", m.Info.PushName))
+	str.WriteString(m.T("menu.divider") + "\n")
 	
 	// Quick start guide
-	str.WriteString("*🚀 Quick Start Guide:*\n\n")
-	str.WriteString("1. *Main Menu:* .menu\n")
-	str.WriteString("2. *Category Menu:* .menu [category]\n")
-	str.WriteString("3. *Detailed Help:* .help\n\n")
+	str.WriteString(m.T("menu.quick_start"))
 	
 	// Popular commands
-	str.WriteString("*🔥 Popular Commands:*\n\n")
-	str.WriteString("📥 *Download Commands:*\n")
-	str.WriteString("• .p [query] - Download YouTube MP3\n")
-	str.WriteString("• .tt [url] - Download TikTok video\n")
-	str.WriteString("• .yt [query] - Search YouTube\n\n")
-	
-	str.WriteString("🏠 *Main Commands:*\n")
-	str.WriteString("• .menu - Show main menu\n")
-	str.WriteString("• .ping - Check bot status\n")
-	str.WriteString("• .perf - Show performance stats\n\n")
+	str.WriteString(m.T("menu.popular"))
 	
 	// Category examples
-	str.WriteString("*📂 Category Examples:*\n\n")
-	categories := getAvailableCategories()
+	str.WriteString(m.T("menu.category_examples"))
+	categories := getAvailableCategories(m)
 	for _, category := range categories {
 		displayName := getCategoryDisplayName(category)
 		str.WriteString(fmt.Sprintf("• .menu %s - %s\n", strings.ToLower(category), displayName))
 	}
 	
 	// Footer
-	str.WriteString("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString("💡 *Need more help?*\n")
-	str.WriteString("• Contact the bot owner\n")
-	str.WriteString("• Check .menu for all commands\n")
-	str.WriteString("🔧 Powered by ZUMYGO Bot")
+	str.WriteString("\n" + m.T("menu.divider"))
+	str.WriteString(m.T("menu.more_help"))
+	str.WriteString(m.T("menu.powered"))
 	
 	m.Reply(str.String())
 	return true
 }
 
 // menu handles both main menu and category-specific menus
-func menu(conn *libs.IClient, m *libs.IMessage) bool {
+func menu(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 	// Check if a specific category is requested
 	if len(m.Args) > 0 {
 		category := strings.Join(m.Args, " ")
@@ -242,7 +241,7 @@ func menu(conn *libs.IClient, m *libs.IMessage) bool {
 }
 
 // help command handler
-func help(conn *libs.IClient, m *libs.IMessage) bool {
+func help(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 	return helpMenu(conn, m)
 }
 
@@ -251,13 +250,13 @@ func createSimpleMenu(conn *libs.IClient, m *libs.IMessage) bool {
 	var str strings.Builder
 	
 	// Professional header
-	str.WriteString("🎯 *ZUMYGO BOT - COMPLETE COMMAND LIST*\n")
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString(fmt.Sprintf("👤 User: %s\n", m.Info.PushName))
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+	str.WriteString(m.T("menu.list_title"))
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.user", m.Info.PushName))
+	str.WriteString(m.T("menu.divider") + "\n")
 	
 	var tags map[string][]item
-	for _, list := range libs.GetList() {
+	for _, list := range visibleCommands(m) {
 		if tags == nil {
 			tags = make(map[string][]item)
 		}
@@ -289,7 +288,7 @@ func createSimpleMenu(conn *libs.IClient, m *libs.IMessage) bool {
 		for _, e := range tags[key] {
 			var prefix string
 			if e.IsPrefix {
-				prefix, _ = libs.ExtractPrefix(m.Body)
+				prefix = m.Prefix
 			} else {
 				prefix = ""
 			}
@@ -302,9 +301,9 @@ func createSimpleMenu(conn *libs.IClient, m *libs.IMessage) bool {
 	}
 
 	// Footer
-	str.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	str.WriteString("💡 *Usage:* .menu [category]\n")
-	str.WriteString("🔧 Powered by ZUMYGO Bot")
+	str.WriteString(m.T("menu.divider"))
+	str.WriteString(m.T("menu.list_usage"))
+	str.WriteString(m.T("menu.powered"))
 
 	m.Reply(str.String())
 	return true
@@ -316,7 +315,7 @@ func init() {
 		As:          []string{"menu"},
 		Tags:        "main",
 		IsPrefix:    true,
-		Description: "Show bot menu with professional formatting",
+		Description: "desc.menu",
 		Execute:     menu,
 	})
 	
@@ -325,7 +324,7 @@ func init() {
 		As:          []string{"help", "h", "?"},
 		Tags:        "main",
 		IsPrefix:    true,
-		Description: "Show detailed help and usage guide",
+		Description: "desc.help",
 		Execute:     help,
 	})
 }
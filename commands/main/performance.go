@@ -1,16 +1,29 @@
 package commands
 
 import (
+	"context"
 	"zumygo/helpers"
 	"zumygo/libs"
 )
 
-func performance(conn *libs.IClient, m *libs.IMessage) bool {
+func performance(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 	// Get performance monitor
 	monitor := helpers.GetPerformanceMonitor()
 	
-	// Get performance report
-	report := monitor.GetPerformanceReport()
+	// Build the report in the reader's language
+	stats := monitor.GetStats()
+	report := m.T("perf.report",
+		stats["uptime"],
+		stats["messages_total"], stats["messages_per_minute"],
+		stats["commands_total"], stats["commands_per_minute"],
+		stats["errors_total"], stats["errors_per_minute"],
+		stats["db_operations"], stats["db_ops_per_minute"],
+		stats["http_requests"], stats["http_per_minute"],
+		stats["cache_hit_rate"],
+		stats["memory_alloc"],
+		stats["goroutines"],
+		stats["gc_count"],
+		stats["queue_depth"], stats["queue_dropped"], stats["queue_wait_avg"], stats["queue_wait_max"])
 	
 	// Send the report
 	m.Reply(report)
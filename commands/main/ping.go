@@ -1,7 +1,7 @@
 package commands
 
 import (
-	"fmt"
+	"context"
 	"zumygo/libs"
 	"time"
 )
@@ -12,12 +12,11 @@ func init() {
 		As:       []string{"ping"},
 		Tags:     "main",
 		IsPrefix: true,
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 			start := time.Now()
 			messageTime := time.Unix(m.Info.Timestamp.Unix(), 0)
 			ping := start.Sub(messageTime).Seconds()
-			response := fmt.Sprintf("*Ping :* %.2f Detik\n", ping)
-			m.Reply(response)
+			m.Reply(m.T("ping.result", ping))
 			return true
 		},
 	})
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"zumygo/libs"
+)
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "(register|daftar|reg)",
+		As:          []string{"register"},
+		Tags:        "main",
+		IsPrefix:    true,
+		Description: "desc.register",
+		IsSession:   true,
+		Timeout:     5 * time.Minute,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			if m.User == nil {
+				m.Reply(m.T("common.db_unavailable"))
+				return false
+			}
+
+			if m.User.Registered {
+				m.Reply(m.T("register.already", m.User.Name))
+				return false
+			}
+
+			answer, err := libs.Ask(ctx, m, m.T("register.ask_name"), 0)
+			if err != nil {
+				return sessionFailed(m, err)
+			}
+			name := strings.TrimSpace(answer.Body)
+			if name == "" || utf8.RuneCountInString(name) > 32 {
+				m.Reply(m.T("register.name_invalid"))
+				return false
+			}
+
+			answer, err = libs.Ask(ctx, answer, m.T("register.ask_age"), 0)
+			if err != nil {
+				return sessionFailed(m, err)
+			}
+			age, convErr := strconv.Atoi(strings.TrimSpace(answer.Body))
+			if convErr != nil || age < 5 || age > 100 {
+				m.Reply(m.T("register.age_invalid"))
+				return false
+			}
+
+			ok, err := libs.Confirm(ctx, answer, m.T("register.confirm", name, age), 0)
+			if err != nil {
+				return sessionFailed(m, err)
+			}
+			if !ok {
+				m.Reply(m.T("register.cancelled"))
+				return false
+			}
+
+			m.User.Name = name
+			m.User.Age = age
+			m.User.RegTime = time.Now().Unix()
+			m.User.Registered = true
+
+			m.Reply(m.T("register.done", name, age))
+			return true
+		},
+	})
+}
+
+// sessionFailed replies with the reason a multi-step command stopped
+func sessionFailed(m *libs.IMessage, err error) bool {
+	switch {
+	case errors.Is(err, libs.ErrSessionTimeout):
+		m.Reply(m.T("common.no_answer"))
+	case errors.Is(err, libs.ErrSessionCancelled):
+		m.Reply(m.T("common.cancelled"))
+	}
+	return false
+}
@@ -1,158 +1,134 @@
 package commands
 
 import (
+	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"zumygo/libs"
 	Auto "zumygo/commands/Auto"
 )
 
+// intervalArgs declares the arguments of "autobio interval"
+var intervalArgs = []libs.IArg{
+	{Name: "minutes", Type: libs.ArgInt, Required: true, Description: "arg.autobio.minutes"},
+}
+
 func init() {
 	libs.NewCommands(&libs.ICommand{
 		Name:        "(autobio|bio)",
 		As:          []string{"autobio"},
 		Tags:        "owner",
 		IsPrefix:    true,
-		IsOwner:     true,
-		Description: "Control auto update bio system",
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
+		Permission:  libs.PermOwner,
+		Description: "desc.autobio",
+		Args: []libs.IArg{
+			{Name: "action", Type: libs.ArgEnum, Choices: []string{"on", "off", "enable", "disable", "template", "interval", "update", "now", "status"}, Description: "arg.autobio.action"},
+			{Name: "value", Type: libs.ArgText, Description: "arg.autobio.value"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 			bioSystem := Auto.GetGlobalBioSystem()
 			if bioSystem == nil {
-				m.Reply("❎ Bio system not available")
+				m.Reply(m.T("autobio.unavailable"))
 				return false
 			}
 
 			// Check if arguments provided
-			if len(m.Args) == 0 {
+			if !m.Params.Has("action") {
 				// Show current status
-				status := bioSystem.GetStatus()
-				enabled := "❌ Disabled"
-				running := "❌ Stopped"
-				
-				if status["enabled"].(bool) {
-					enabled = "✅ Enabled"
-				}
-				if status["running"].(bool) {
-					running = "✅ Running"
-				}
-
-				message := fmt.Sprintf(`*📝 Auto Bio System Status*
-
-*Status:* %s
-*Running:* %s
-*Template:* %s
-*Interval:* %d minutes
-
-*Usage:*
-• .autobio on/off - Enable/disable auto update
-• .autobio template <text> - Set bio template
-• .autobio interval <minutes> - Set update interval
-• .autobio update - Force update now
-• .autobio status - Show this status
-
-*Template Variables:*
-• {time} - Current time (HH:MM)
-• {status} - Bot status
-• {web} - Website URL
-• {uptime} - Bot uptime
-• {commands} - Command count
-• {users} - User count
-• {groups} - Group count`, 
-					enabled, running, 
-					status["template"], 
-					status["interval"])
+				message := bioStatus(m, bioSystem.GetStatus()) + "\n\n" +
+					libs.Usage(m.Cmd, m.Prefix, m.Lang()) + "\n\n" +
+					m.T("autobio.variables")
 
 				m.Reply(message)
 				return true
 			}
 
-			subCommand := strings.ToLower(m.Args[0])
+			subCommand := m.Params.String("action")
+			value := m.Params.String("value")
 
 			switch subCommand {
 			case "on", "enable":
 				enabled := bioSystem.ToggleAutoUpdate()
 				if enabled {
-					m.Reply("✅ Auto update bio enabled")
+					m.Reply(m.T("autobio.toggled_on"))
 				} else {
-					m.Reply("❌ Auto update bio disabled")
+					m.Reply(m.T("autobio.toggled_off"))
 				}
 
 			case "off", "disable":
 				enabled := bioSystem.ToggleAutoUpdate()
 				if enabled {
-					m.Reply("✅ Auto update bio enabled")
+					m.Reply(m.T("autobio.toggled_on"))
 				} else {
-					m.Reply("❌ Auto update bio disabled")
+					m.Reply(m.T("autobio.toggled_off"))
 				}
 
 			case "template":
-				if len(m.Args) < 2 {
-					m.Reply("❎ Please provide a template text\n\nExample: .autobio template 🤖 Bot Online | ⏰ {time} | 📊 {status}")
+				if value == "" {
+					m.Reply(m.T("autobio.need_template"))
 					return false
 				}
 				
-				template := strings.Join(m.Args[1:], " ")
+				template := value
 				bioSystem.SetBioTemplate(template)
-				m.Reply(fmt.Sprintf("✅ Bio template updated:\n%s", template))
+				m.Reply(m.T("autobio.template_set", template))
 
 			case "interval":
-				if len(m.Args) < 2 {
-					m.Reply("❎ Please provide interval in minutes\n\nExample: .autobio interval 30")
+				// The interval is a whole number of minutes after the action
+				sub := *m
+				sub.Args = m.Args[1:]
+				params, err := libs.ParseArgs(intervalArgs, conn, &sub)
+				if err != nil {
+					message := err.Error()
+					if argErr, ok := err.(*libs.ArgError); ok {
+						message = argErr.Localize(m.Lang())
+					}
+					usage := libs.Usage(&libs.ICommand{Name: m.Command + " interval", Args: intervalArgs}, m.Prefix, m.Lang())
+					m.Reply(fmt.Sprintf("❎ %s\n\n%s", message, usage))
 					return false
 				}
 				
-				minutes, err := strconv.Atoi(m.Args[1])
-				if err != nil || minutes < 1 {
-					m.Reply("❎ Invalid interval. Must be a positive number")
+				minutes := params.Int("minutes")
+				if minutes < 1 {
+					m.Reply(m.T("autobio.bad_interval"))
 					return false
 				}
 				
 				if err := bioSystem.SetBioInterval(minutes); err != nil {
-					m.Reply(fmt.Sprintf("❎ Failed to set interval: %v", err))
+					m.Reply(m.T("autobio.interval_fail", err))
 					return false
 				}
 				
-				m.Reply(fmt.Sprintf("✅ Bio update interval set to %d minutes", minutes))
+				m.Reply(m.T("autobio.interval_set", minutes))
 
 			case "update", "now":
 				if err := bioSystem.UpdateBioNow(); err != nil {
-					m.Reply(fmt.Sprintf("❎ Failed to update bio: %v", err))
+					m.Reply(m.T("autobio.update_fail", err))
 					return false
 				}
 				
-				m.Reply("✅ Bio updated successfully")
+				m.Reply(m.T("autobio.updated"))
 
 			case "status":
 				// Show current status (same as no args)
-				status := bioSystem.GetStatus()
-				enabled := "❌ Disabled"
-				running := "❌ Stopped"
-				
-				if status["enabled"].(bool) {
-					enabled = "✅ Enabled"
-				}
-				if status["running"].(bool) {
-					running = "✅ Running"
-				}
-
-				message := fmt.Sprintf(`*📝 Auto Bio System Status*
-
-*Status:* %s
-*Running:* %s
-*Template:* %s
-*Interval:* %d minutes`, 
-					enabled, running, 
-					status["template"], 
-					status["interval"])
-
-				m.Reply(message)
-
-			default:
-				m.Reply("❎ Unknown subcommand. Use: .autobio on/off/template/interval/update/status")
+				m.Reply(bioStatus(m, bioSystem.GetStatus()))
 			}
 
 			return true
 		},
 	})
-} 
\ No newline at end of file
+}
+
+// bioStatus renders the auto bio state in the reader's language
+func bioStatus(m *libs.IMessage, status map[string]interface{}) string {
+	enabled := m.T("autobio.disabled")
+	running := m.T("autobio.stopped")
+
+	if status["enabled"].(bool) {
+		enabled = m.T("autobio.enabled")
+	}
+	if status["running"].(bool) {
+		running = m.T("autobio.running")
+	}
+
+	return m.T("autobio.status", enabled, running, status["template"], status["interval"])
+}
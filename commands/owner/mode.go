@@ -1,27 +1,29 @@
 package commands
 
 import (
+	"context"
 	"zumygo/libs"
 	"zumygo/config"
 )
 
 func init() {
 	libs.NewCommands(&libs.ICommand{
-		Name:     `mode`,
-		As:       []string{"mode"},
-		Tags:     "owner",
-		IsPrefix: true,
-		IsOwner:  true,
-		Execute: func(conn *libs.IClient, m *libs.IMessage) bool {
+		Name:        `mode`,
+		As:          []string{"mode"},
+		Tags:        "owner",
+		IsPrefix:    true,
+		Permission:  libs.PermOwner,
+		Description: "desc.mode",
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
 			cfg := config.Config
 			var message string
 
 			if cfg.PublicMode {
 				cfg.PublicMode = false
-				message = "The bot is now in private mode."
+				message = m.T("mode.private")
 			} else {
 				cfg.PublicMode = true
-				message = "The bot is now in public mode."
+				message = m.T("mode.public")
 			}
 
 			m.Reply(message)
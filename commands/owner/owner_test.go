@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+	Auto "zumygo/commands/Auto"
+	"zumygo/config"
+	"zumygo/libs"
+	"zumygo/testkit"
+)
+
+func TestScheduleListCancel(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.Owner, ".schedule 2h --to 6282222222222 hello there")
+	jobs := libs.ListJobs()
+	if len(jobs) != 1 {
+		t.Fatalf("jobs = %d, want 1 (reply %q)", len(jobs), h.LastReply())
+	}
+	job := jobs[0]
+	if job.Chat != "6282222222222@s.whatsapp.net" || job.Text != "hello there" || job.Kind != libs.JobMessage {
+		t.Errorf("job = %+v", job)
+	}
+	if wait := time.Until(time.Unix(job.NextRun, 0)); wait < 119*time.Minute || wait > 2*time.Hour {
+		t.Errorf("next run in %v, want 2h", wait)
+	}
+
+	h.Private(h.Owner, ".cron --cmd 0 8 * * 1-5 menu")
+	if jobs = libs.ListJobs(); len(jobs) != 2 {
+		t.Fatalf("jobs = %d, want 2 (reply %q)", len(jobs), h.LastReply())
+	}
+	for _, j := range jobs {
+		if j.Cron != "" && (j.Cron != "0 8 * * 1-5" || j.Text != ".menu" || j.Kind != libs.JobCommand) {
+			t.Errorf("cron job = %+v", j)
+		}
+	}
+
+	h.Private(h.Owner, ".jobs")
+	if reply := h.LastReply(); !strings.Contains(reply, job.ID) {
+		t.Errorf("jobs list %q does not mention %s", reply, job.ID)
+	}
+
+	h.Private(h.Owner, ".unschedule "+job.ID)
+	if jobs = libs.ListJobs(); len(jobs) != 1 || jobs[0].ID == job.ID {
+		t.Errorf("job %s still scheduled", job.ID)
+	}
+}
+
+func TestScheduleOwnerOnly(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, ".schedule 1h hi")
+	if jobs := libs.ListJobs(); len(jobs) != 0 {
+		t.Errorf("non-owner scheduled %d jobs", len(jobs))
+	}
+}
+
+func TestSuggestSkipsOwnerCommands(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Private(h.User, ".jobz")
+	if replies := h.Replies(); len(replies) != 0 {
+		t.Errorf("replies to user = %q, want none", replies)
+	}
+
+	h.Private(h.Owner, ".jobz")
+	if reply := h.LastReply(); !strings.Contains(reply, "jobs") {
+		t.Errorf("reply to owner = %q, want a suggestion for jobs", reply)
+	}
+}
+
+func TestParseJobTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 30, 0, 0, time.Local)
+
+	cases := map[string]time.Time{
+		"45m":              now.Add(45 * time.Minute),
+		"1d":               now.Add(24 * time.Hour),
+		"18:00":            time.Date(2026, 10, 16, 18, 0, 0, 0, time.Local),
+		"09:00":            time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local),
+		"2026-12-25":       time.Date(2026, 12, 25, 0, 0, 0, 0, time.Local),
+		"2026-12-25T07:15": time.Date(2026, 12, 25, 7, 15, 0, 0, time.Local),
+	}
+	for value, want := range cases {
+		got, ok := parseJobTime(value, now)
+		if !ok || !got.Equal(want) {
+			t.Errorf("parseJobTime(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+
+	for _, value := range []string{"", "soon", "25:00", "2026-13-01"} {
+		if _, ok := parseJobTime(value, now); ok {
+			t.Errorf("parseJobTime(%q) succeeded, want failure", value)
+		}
+	}
+}
+
+func TestAutobioIntervalIsNumber(t *testing.T) {
+	h := testkit.New(t)
+	Auto.SetGlobalBioSystem(Auto.InitializeBioSystem(config.Config, nil))
+	defer Auto.SetGlobalBioSystem(nil)
+
+	h.Private(h.Owner, ".autobio interval soon")
+	if reply := h.LastReply(); !strings.Contains(reply, "minutes") || config.Config.BioInterval == 0 {
+		t.Errorf("reply = %q, want the usage for a bad interval", reply)
+	}
+
+	h.Private(h.Owner, ".autobio interval 15")
+	if config.Config.BioInterval != 15 {
+		t.Errorf("BioInterval = %d, want 15 (reply %q)", config.Config.BioInterval, h.LastReply())
+	}
+}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+	"zumygo/database"
+	"zumygo/libs"
+)
+
+// jobTimeLayout is how job times are shown and typed as dates
+const jobTimeLayout = "2006-01-02 15:04"
+
+func init() {
+	libs.NewCommands(&libs.ICommand{
+		Name:        "schedule",
+		As:          []string{"schedule"},
+		Tags:        "owner",
+		IsPrefix:    true,
+		Permission:  libs.PermOwner,
+		Description: "desc.schedule",
+		Args: []libs.IArg{
+			{Name: "when", Type: libs.ArgString, Required: true, Description: "arg.schedule.when"},
+			{Name: "to", Type: libs.ArgOption, Description: "arg.schedule.to"},
+			{Name: "cmd", Type: libs.ArgFlag, Description: "arg.schedule.cmd"},
+			{Name: "text", Type: libs.ArgText, Required: true, Description: "arg.schedule.text"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			runAt, ok := parseJobTime(m.Params.String("when"), time.Now())
+			if !ok {
+				m.Reply(m.T("schedule.bad_time", m.Params.String("when")))
+				return false
+			}
+
+			job, ok := newJob(conn, m)
+			if !ok {
+				return false
+			}
+			job.RunAt = runAt.Unix()
+			return scheduleJob(m, job)
+		},
+	})
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "cron",
+		As:          []string{"cron"},
+		Tags:        "owner",
+		IsPrefix:    true,
+		Permission:  libs.PermOwner,
+		Description: "desc.cron",
+		Args: []libs.IArg{
+			{Name: "to", Type: libs.ArgOption, Description: "arg.schedule.to"},
+			{Name: "cmd", Type: libs.ArgFlag, Description: "arg.schedule.cmd"},
+			{Name: "expr", Type: libs.ArgText, Required: true, Description: "arg.cron.expr"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			fields := strings.Fields(m.Params.String("expr"))
+
+			// A macro is one field, a full expression five
+			count := 5
+			if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+				count = 1
+			}
+			if len(fields) <= count {
+				m.Reply(libs.Usage(m.Cmd, m.Prefix, m.Lang()))
+				return false
+			}
+
+			expr := strings.Join(fields[:count], " ")
+			if _, err := libs.ParseCron(expr); err != nil {
+				m.Reply(m.T("schedule.bad_cron", err.Error()))
+				return false
+			}
+
+			job, ok := newJob(conn, m)
+			if !ok {
+				return false
+			}
+			job.Cron = expr
+			job.Text = jobText(job.Kind, strings.Join(fields[count:], " "), job.Chat)
+			return scheduleJob(m, job)
+		},
+	})
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "jobs",
+		As:          []string{"jobs"},
+		Tags:        "owner",
+		IsPrefix:    true,
+		Permission:  libs.PermOwner,
+		Description: "desc.jobs",
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			jobs := libs.ListJobs()
+			if len(jobs) == 0 {
+				m.Reply(m.T("schedule.none"))
+				return true
+			}
+
+			var str strings.Builder
+			str.WriteString(m.T("schedule.list_title"))
+			for _, job := range jobs {
+				when := m.T("schedule.once")
+				if job.Cron != "" {
+					when = "`" + job.Cron + "`"
+				}
+				text := job.Text
+				if runes := []rune(text); len(runes) > 60 {
+					text = string(runes[:60]) + "..."
+				}
+				str.WriteString(m.T("schedule.list_item", job.ID, m.T("schedule.kind_"+job.Kind), when, job.Chat,
+					time.Unix(job.NextRun, 0).Format(jobTimeLayout), text))
+			}
+			str.WriteString(m.T("schedule.list_footer", m.Prefix))
+
+			m.Reply(str.String())
+			return true
+		},
+	})
+
+	libs.NewCommands(&libs.ICommand{
+		Name:        "(unschedule|deljob)",
+		As:          []string{"unschedule"},
+		Tags:        "owner",
+		IsPrefix:    true,
+		Permission:  libs.PermOwner,
+		Description: "desc.unschedule",
+		Args: []libs.IArg{
+			{Name: "id", Type: libs.ArgString, Required: true, Description: "arg.unschedule.id"},
+		},
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			id := strings.ToLower(m.Params.String("id"))
+			if !libs.CancelJob(id) {
+				m.Reply(m.T("schedule.not_found", id))
+				return false
+			}
+			m.Reply(m.T("schedule.cancelled", id))
+			return true
+		},
+	})
+}
+
+// newJob builds a job from the shared --to, --cmd and text parameters
+func newJob(conn *libs.IClient, m *libs.IMessage) (database.Job, bool) {
+	chat := m.Info.Chat
+	if to := m.Params.String("to"); to != "" {
+		jid, ok := conn.ParseJID(strings.TrimPrefix(to, "@"))
+		if !ok {
+			m.Reply(m.T("schedule.bad_chat", to))
+			return database.Job{}, false
+		}
+		chat = jid
+	}
+
+	kind := libs.JobMessage
+	if m.Params.Flag("cmd") {
+		kind = libs.JobCommand
+	}
+
+	job := database.Job{
+		Chat:   chat.String(),
+		Sender: m.Sender.ToNonAD().String(),
+		Kind:   kind,
+	}
+	job.Text = jobText(kind, m.Params.String("text"), job.Chat)
+	return job, true
+}
+
+// jobText adds the target chat prefix to commands written without one
+func jobText(kind, text, chat string) string {
+	if kind != libs.JobCommand {
+		return text
+	}
+	if _, ok := libs.ExtractPrefix(chat, text); ok {
+		return text
+	}
+	if prefixes := libs.GetChatPrefixes(chat); len(prefixes) > 0 {
+		return prefixes[0] + text
+	}
+	return text
+}
+
+// scheduleJob stores the job and replies with the outcome
+func scheduleJob(m *libs.IMessage, job database.Job) bool {
+	job, err := libs.ScheduleJob(job)
+	switch err {
+	case nil:
+	case libs.ErrJobPast:
+		m.Reply(m.T("schedule.past"))
+		return false
+	case libs.ErrJobNever:
+		m.Reply(m.T("schedule.never"))
+		return false
+	case libs.ErrJobNoStore:
+		m.Reply(m.T("common.db_unavailable"))
+		return false
+	default:
+		m.Reply(m.T("schedule.bad_cron", err.Error()))
+		return false
+	}
+
+	m.Reply(m.T("schedule.done", job.ID, time.Unix(job.NextRun, 0).Format(jobTimeLayout), job.Chat))
+	return true
+}
+
+// parseJobTime reads a delay (30m, 2h, 1d), a time of day (HH:MM, today or
+// tomorrow) or a date with optional time (YYYY-MM-DD, YYYY-MM-DDTHH:MM)
+func parseJobTime(value string, now time.Time) (time.Time, bool) {
+	if delay, ok := libs.ParseDuration(value); ok {
+		return now.Add(delay), true
+	}
+
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, true
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"encoding/json"
 	"strings"
+	"zumygo/locales"
+	"zumygo/phone"
 )
 
 // BotConfig holds all bot configuration
@@ -17,6 +19,9 @@ type BotConfig struct {
 	NumberBot   string   `json:"numberbot"`
 	Mail        string   `json:"mail"`
 	
+	// Calling code for numbers written with a leading 0
+	DefaultCountry string `json:"default_country"`
+	
 	// Payment Info
 	Dana   string `json:"dana"`
 	Pulsa  string `json:"pulsa"`
@@ -80,10 +85,26 @@ type BotConfig struct {
 	Prefix     string   `json:"prefix"`
 	Prefixes   []string `json:"prefixes"`
 	
+	// Default reply language, see the locales package
+	Language string `json:"language"`
+	
 	// Bot Mode Settings
 	PublicMode  bool `json:"public_mode"`
 	ReadStatus  bool `json:"read_status"`
 	ReactStatus bool `json:"react_status"`
+	Interactive bool `json:"interactive"` // tap-driven menus and search results
+	
+	// Message Queue Settings
+	Workers   int `json:"workers"`    // concurrent message workers
+	QueueSize int `json:"queue_size"` // messages waiting before new ones are dropped
+	
+	// Media Settings
+	MaxDownloadSize int `json:"max_download_size"` // in MB, for media downloads
+	
+	// Cooldown Settings
+	CooldownExemptOwner   bool `json:"cooldown_exempt_owner"`
+	CooldownExemptPremium bool `json:"cooldown_exempt_premium"`
+	PersistCooldowns      bool `json:"persist_cooldowns"`
 	
 	// Profile Bio Settings
 	AutoUpdateBio bool   `json:"auto_update_bio"`
@@ -118,6 +139,9 @@ func LoadConfig() *BotConfig {
 		NumberBot:   "6281253216363",
 		Mail:        "anggahrm@gmail.com",
 		
+		// Numbers written with a leading 0 are Indonesian
+		DefaultCountry: phone.DefaultCountry,
+		
 		// Payment Info
 		Dana:  "6285123865643",
 		Pulsa: "6285123865643",
@@ -197,10 +221,26 @@ func LoadConfig() *BotConfig {
 		Prefix:     ".",
 		Prefixes:   []string{".", "!", "#", "$", "&", "?"},
 		
+		// Default reply language
+		Language: locales.ID,
+		
 		// Bot Mode Settings
 		PublicMode:  false, // Private mode by default
 		ReadStatus:  true,  // Auto-read status enabled by default
 		ReactStatus: true,  // Auto-react status enabled by default
+		Interactive: true,  // Menus and search results as native flow messages
+		
+		// Message Queue Settings
+		Workers:   10,   // Concurrent message workers
+		QueueSize: 2000, // Waiting messages before new ones are dropped
+		
+		// Media Settings
+		MaxDownloadSize: 100, // Refuse media downloads above 100 MB
+		
+		// Cooldown Settings
+		CooldownExemptOwner:   true,  // Owners skip command cooldowns
+		CooldownExemptPremium: true,  // Premium users skip command cooldowns
+		PersistCooldowns:      false, // Keep cooldowns in memory only
 		
 		// Profile Bio Settings
 		AutoUpdateBio: false, // Auto update bio disabled by default
@@ -215,10 +255,22 @@ func LoadConfig() *BotConfig {
 		SessionName:   "session",
 	}
 	
+	config.ApplyMessages()
+	
 	Config = config
 	return config
 }
 
+// ApplyMessages publishes the configured bot messages as the Indonesian
+// catalog entries, so replies pick up edits to these fields
+func (c *BotConfig) ApplyMessages() {
+	locales.Override(locales.ID, "common.wait", c.Wait)
+	locales.Override(locales.ID, "common.error", c.Error)
+	locales.Override(locales.ID, "common.correct", c.Benar)
+	locales.Override(locales.ID, "common.wrong", c.Salah)
+	locales.Override(locales.ID, "sticker.wait", c.StikerWait)
+}
+
 // API builds API URL with query parameters
 func (c *BotConfig) API(name, path string, query map[string]string) string {
 	baseURL, exists := c.APIs[name]
@@ -253,34 +305,19 @@ func (c *BotConfig) API(name, path string, query map[string]string) string {
 	return url
 }
 
-// IsOwner checks if the given number is an owner
+// IsOwner checks if the given number or JID is an owner
 func (c *BotConfig) IsOwner(number string) bool {
-	for _, owner := range c.Owner {
-		if owner == number {
-			return true
-		}
-	}
-	return false
+	return phone.Match(c.Owner, number, c.DefaultCountry)
 }
 
-// IsMod checks if the given number is a moderator
+// IsMod checks if the given number or JID is a moderator
 func (c *BotConfig) IsMod(number string) bool {
-	for _, mod := range c.Mods {
-		if mod == number {
-			return true
-		}
-	}
-	return false
+	return phone.Match(c.Mods, number, c.DefaultCountry)
 }
 
-// IsPrem checks if the given number is premium
+// IsPrem checks if the given number or JID is premium
 func (c *BotConfig) IsPrem(number string) bool {
-	for _, prem := range c.Prems {
-		if prem == number {
-			return true
-		}
-	}
-	return false
+	return phone.Match(c.Prems, number, c.DefaultCountry)
 }
 
 // TogglePublicMode toggles the public mode setting
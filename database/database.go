@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 	"bytes"
@@ -17,6 +18,7 @@ type User struct {
 	Age          int       `json:"age"`
 	RegTime      int64     `json:"regTime"`
 	Registered   bool      `json:"registered"`
+	Language     string    `json:"language"`
 	
 	// Experience & Level
 	Exp          int64     `json:"exp"`
@@ -64,6 +66,11 @@ type Chat struct {
 	AntiToxic   bool   `json:"antiToxic"`
 	AntiVirtex  bool   `json:"antiVirtex"`
 	Viewonce    bool   `json:"viewonce"`
+	Disabled    []string `json:"disabled"`
+	NoSuggest   bool   `json:"noSuggest"`
+	Language    string `json:"language"`
+	Prefixes    []string `json:"prefixes"`
+	NoPrefix    bool   `json:"noPrefix"`
 	
 	// Activity
 	LastActivity int64 `json:"lastActivity"`
@@ -82,6 +89,20 @@ type Stats struct {
 	Commands      map[string]int64 `json:"commands"`
 }
 
+// Job represents a scheduled message or command
+type Job struct {
+	ID        string `json:"id"`
+	Chat      string `json:"chat"`
+	Sender    string `json:"sender"`
+	Kind      string `json:"kind"`
+	Text      string `json:"text"`
+	Cron      string `json:"cron,omitempty"`  // Cron expression for repeating jobs
+	RunAt     int64  `json:"runAt,omitempty"` // Unix time for one-shot jobs
+	NextRun   int64  `json:"nextRun"`
+	LastRun   int64  `json:"lastRun,omitempty"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
 // Database represents the main database structure
 type Database struct {
 	Users              map[string]*User `json:"users"`
@@ -91,6 +112,9 @@ type Database struct {
 	Stickers           map[string]interface{} `json:"sticker"`
 	Settings           map[string]interface{} `json:"settings"`
 	Responses          map[string]interface{} `json:"respon"`
+	Cooldowns          map[string][]int64     `json:"cooldowns"`
+	Disabled           []string               `json:"disabled"`
+	Jobs               map[string]*Job        `json:"jobs"`
 
 	
 	// Internal
@@ -119,6 +143,8 @@ func InitDatabase(filename string) (*Database, error) {
 		Stickers:           make(map[string]interface{}),
 		Settings:           make(map[string]interface{}),
 		Responses:          make(map[string]interface{}),
+		Cooldowns:          make(map[string][]int64),
+		Jobs:               make(map[string]*Job),
 
 		filename:        filename,
 		dirty:           false,
@@ -208,8 +234,11 @@ func (db *Database) Save() error {
 	return nil
 }
 
-// GetUser gets or creates a user with performance optimizations
+// GetUser gets or creates a user with performance optimizations. The JID is
+// stored under its canonical key, see UserKey.
 func (db *Database) GetUser(jid string) *User {
+	jid = UserKey(jid)
+	
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 	
@@ -271,7 +300,7 @@ func (db *Database) GetChat(jid string) *Chat {
 			SBye:         "",
 			SPromote:     "",
 			SDemote:      "",
-			Delete:       true,
+			Delete:       isGroupChat(jid),
 			AntiLink:     false,
 			AntiLink2:    false,
 			AntiToxic:    false,
@@ -289,6 +318,11 @@ func (db *Database) GetChat(jid string) *Chat {
 	return chat
 }
 
+// isGroupChat reports whether the chat JID belongs to a group
+func isGroupChat(jid string) bool {
+	return strings.HasSuffix(jid, "@g.us")
+}
+
 // cleanupOldUsers removes inactive users to free memory
 func (db *Database) cleanupOldUsers() {
 	now := time.Now().Unix()
@@ -333,6 +367,278 @@ func (db *Database) IncrementMessages() {
 	db.dirty = true
 }
 
+// GetCooldown returns a copy of the persisted usage timestamps for a cooldown key
+func (db *Database) GetCooldown(key string) []int64 {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	stamps := db.Cooldowns[key]
+	if len(stamps) == 0 {
+		return nil
+	}
+	
+	result := make([]int64, len(stamps))
+	copy(result, stamps)
+	return result
+}
+
+// SetCooldown persists the usage timestamps for a cooldown key, removing it when empty
+func (db *Database) SetCooldown(key string, stamps []int64) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	if db.Cooldowns == nil {
+		db.Cooldowns = make(map[string][]int64)
+	}
+	
+	if len(stamps) == 0 {
+		delete(db.Cooldowns, key)
+	} else {
+		saved := make([]int64, len(stamps))
+		copy(saved, stamps)
+		db.Cooldowns[key] = saved
+	}
+	db.dirty = true
+}
+
+// PruneCooldowns removes persisted cooldown keys whose newest usage is at or
+// before cutoff, in Unix nanoseconds, and returns how many were removed
+func (db *Database) PruneCooldowns(cutoff int64) int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	removed := 0
+	for key, stamps := range db.Cooldowns {
+		if len(stamps) == 0 || stamps[len(stamps)-1] <= cutoff {
+			delete(db.Cooldowns, key)
+			removed++
+		}
+	}
+	if removed > 0 {
+		db.dirty = true
+	}
+	return removed
+}
+
+// GetDisabledCommands returns a copy of the disabled command keys of a chat,
+// or the global ones when chatID is empty
+func (db *Database) GetDisabledCommands(chatID string) []string {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	keys := db.Disabled
+	if chatID != "" {
+		chat, exists := db.Chats[chatID]
+		if !exists {
+			return nil
+		}
+		keys = chat.Disabled
+	}
+	
+	if len(keys) == 0 {
+		return nil
+	}
+	
+	result := make([]string, len(keys))
+	copy(result, keys)
+	return result
+}
+
+// SetCommandDisabled disables or enables a command key for a chat, or
+// globally when chatID is empty, and reports whether anything changed
+func (db *Database) SetCommandDisabled(chatID, key string, disabled bool) bool {
+	if chatID != "" {
+		db.GetChat(chatID)
+	}
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	keys := &db.Disabled
+	if chatID != "" {
+		keys = &db.Chats[chatID].Disabled
+	}
+	
+	for i, existing := range *keys {
+		if existing == key {
+			if disabled {
+				return false
+			}
+			*keys = append((*keys)[:i], (*keys)[i+1:]...)
+			db.dirty = true
+			return true
+		}
+	}
+	
+	if !disabled {
+		return false
+	}
+	
+	*keys = append(*keys, key)
+	db.dirty = true
+	return true
+}
+
+// GetChatPrefixes returns a copy of the chat prefix overrides and whether the
+// chat accepts commands without a prefix, without creating the chat
+func (db *Database) GetChatPrefixes(chatID string) ([]string, bool) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	chat, exists := db.Chats[chatID]
+	if !exists {
+		return nil, false
+	}
+	
+	var prefixes []string
+	if len(chat.Prefixes) > 0 {
+		prefixes = make([]string, len(chat.Prefixes))
+		copy(prefixes, chat.Prefixes)
+	}
+	return prefixes, chat.NoPrefix
+}
+
+// SetChatPrefixes replaces the chat prefix overrides, clearing them when empty
+func (db *Database) SetChatPrefixes(chatID string, prefixes []string) {
+	chat := db.GetChat(chatID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	chat.Prefixes = prefixes
+	db.dirty = true
+}
+
+// SetChatNoPrefix turns the chat no-prefix mode on or off
+func (db *Database) SetChatNoPrefix(chatID string, enabled bool) {
+	chat := db.GetChat(chatID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	chat.NoPrefix = enabled
+	db.dirty = true
+}
+
+// SetChatLanguage sets the language of the chat, clearing it when empty
+func (db *Database) SetChatLanguage(chatID, code string) {
+	chat := db.GetChat(chatID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	chat.Language = code
+	db.dirty = true
+}
+
+// SetUserLanguage sets the language of the user, clearing it when empty
+func (db *Database) SetUserLanguage(userID, code string) {
+	user := db.GetUser(userID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	user.Language = code
+	db.dirty = true
+}
+
+// SetChatSuggest turns typo suggestions for unknown commands in the chat on
+// or off
+func (db *Database) SetChatSuggest(chatID string, enabled bool) {
+	chat := db.GetChat(chatID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	chat.NoSuggest = !enabled
+	db.dirty = true
+}
+
+// GetChatDelete reports whether deleted messages are reposted in the chat,
+// which is the default for groups not stored yet
+func (db *Database) GetChatDelete(chatID string) bool {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	chat, exists := db.Chats[chatID]
+	if !exists {
+		return isGroupChat(chatID)
+	}
+	return chat.Delete
+}
+
+// SetChatDelete turns reposting deleted messages in the chat on or off
+func (db *Database) SetChatDelete(chatID string, enabled bool) {
+	chat := db.GetChat(chatID)
+	
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	chat.Delete = enabled
+	db.dirty = true
+}
+
+// AddJob stores a scheduled job, replacing any job with the same ID
+func (db *Database) AddJob(job *Job) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	if db.Jobs == nil {
+		db.Jobs = make(map[string]*Job)
+	}
+	db.Jobs[job.ID] = job
+	db.dirty = true
+}
+
+// RemoveJob deletes a scheduled job and reports whether it existed
+func (db *Database) RemoveJob(id string) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	if _, exists := db.Jobs[id]; !exists {
+		return false
+	}
+	delete(db.Jobs, id)
+	db.dirty = true
+	return true
+}
+
+// HasJob reports whether a job with the ID is scheduled
+func (db *Database) HasJob(id string) bool {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	_, exists := db.Jobs[id]
+	return exists
+}
+
+// GetJobs returns a copy of every scheduled job
+func (db *Database) GetJobs() []Job {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	
+	jobs := make([]Job, 0, len(db.Jobs))
+	for _, job := range db.Jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// SetJobRun records a job run and its next run time
+func (db *Database) SetJobRun(id string, lastRun, nextRun int64) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	
+	job, exists := db.Jobs[id]
+	if !exists {
+		return false
+	}
+	job.LastRun = lastRun
+	job.NextRun = nextRun
+	db.dirty = true
+	return true
+}
+
 // GetUptime returns bot uptime in seconds
 func (db *Database) GetUptime() int64 {
 	return time.Now().Unix() - db.Stats.StartTime
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"sync"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+var (
+	pnResolver      func(types.JID) types.JID
+	pnResolverMutex sync.RWMutex
+)
+
+// SetPNResolver installs the LID to phone number lookup behind UserKey. The
+// client sets it once the LID store is available.
+func SetPNResolver(resolve func(types.JID) types.JID) {
+	pnResolverMutex.Lock()
+	defer pnResolverMutex.Unlock()
+
+	pnResolver = resolve
+}
+
+// UserKey returns the canonical key users are stored under: the phone
+// number JID without device when known, the LID otherwise. Keys that are
+// not JIDs are kept as they are.
+func UserKey(jid string) string {
+	parsed, err := types.ParseJID(jid)
+	if err != nil || parsed.User == "" {
+		return jid
+	}
+	parsed = parsed.ToNonAD()
+
+	if parsed.Server == types.HiddenUserServer {
+		pnResolverMutex.RLock()
+		resolve := pnResolver
+		pnResolverMutex.RUnlock()
+
+		if resolve != nil {
+			if pn := resolve(parsed); pn.Server == types.DefaultUserServer {
+				parsed = pn.ToNonAD()
+			}
+		}
+	}
+	return parsed.String()
+}
+
+// MergeUsers moves users stored under a LID or a device JID to their
+// canonical key, merging them into any record already there, and returns
+// how many records were rekeyed. Run it once the LID store is available.
+func (db *Database) MergeUsers() int {
+	db.mutex.RLock()
+	keys := make([]string, 0, len(db.Users))
+	for key := range db.Users {
+		keys = append(keys, key)
+	}
+	db.mutex.RUnlock()
+
+	// Resolve outside the lock, lookups may hit the LID store
+	canonical := make(map[string]string)
+	for _, key := range keys {
+		if target := UserKey(key); target != key {
+			canonical[key] = target
+		}
+	}
+	if len(canonical) == 0 {
+		return 0
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	merged := 0
+	for key, target := range canonical {
+		user, exists := db.Users[key]
+		if !exists {
+			continue
+		}
+		delete(db.Users, key)
+
+		if existing, ok := db.Users[target]; ok {
+			mergeUser(existing, user)
+			db.Stats.TotalUsers--
+		} else {
+			db.Users[target] = user
+		}
+		merged++
+	}
+
+	if merged > 0 {
+		db.dirty = true
+	}
+	return merged
+}
+
+// mergeUser folds a duplicate record of the same person into dst, keeping
+// progress from both and the stricter moderation state
+func mergeUser(dst, src *User) {
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	if dst.Age < 0 {
+		dst.Age = src.Age
+	}
+	if !dst.Registered && src.Registered {
+		dst.Registered, dst.RegTime = true, src.RegTime
+	}
+	if dst.Language == "" {
+		dst.Language = src.Language
+	}
+
+	dst.Exp += src.Exp
+	if src.Level > dst.Level {
+		dst.Level, dst.Role = src.Level, src.Role
+	}
+	if dst.Pasangan == "" {
+		dst.Pasangan = src.Pasangan
+	}
+
+	if src.Warn > dst.Warn {
+		dst.Warn = src.Warn
+	}
+	if src.Banned || src.BannedUser {
+		dst.Banned = dst.Banned || src.Banned
+		dst.BannedUser = dst.BannedUser || src.BannedUser
+		if dst.BannedReason == "" {
+			dst.BannedReason = src.BannedReason
+		}
+	}
+
+	if src.LastPM > dst.LastPM {
+		dst.LastPM = src.LastPM
+	}
+	if src.AFK > dst.AFK {
+		dst.AFK, dst.AFKReason = src.AFK, src.AFKReason
+	}
+
+	if src.Premium {
+		dst.Premium = true
+	}
+	if src.PremiumTime > dst.PremiumTime {
+		dst.PremiumTime, dst.PremiumDate = src.PremiumTime, src.PremiumDate
+	}
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestMergeUsers(t *testing.T) {
+	prev := DB
+	t.Cleanup(func() {
+		DB = prev
+		SetPNResolver(nil)
+	})
+
+	db, err := InitDatabase(filepath.Join(t.TempDir(), "database.json"))
+	if err != nil {
+		t.Fatalf("init database: %v", err)
+	}
+
+	lid := db.GetUser("123456789@lid")
+	lid.Exp, lid.Registered, lid.Name = 10, true, "Tester"
+	pn := db.GetUser("6281111111111@s.whatsapp.net")
+	pn.Exp, pn.Warn = 5, 2
+	db.GetUser("6282222222222:3@s.whatsapp.net")
+	db.GetUser("987654321@lid")
+
+	SetPNResolver(func(jid types.JID) types.JID {
+		if jid.User == "123456789" {
+			return types.NewJID("6281111111111", types.DefaultUserServer)
+		}
+		return jid
+	})
+
+	if merged := db.MergeUsers(); merged != 1 {
+		t.Errorf("MergeUsers = %d, want 1", merged)
+	}
+
+	user, ok := db.Users["6281111111111@s.whatsapp.net"]
+	if !ok || user.Exp != 15 || !user.Registered || user.Name != "Tester" || user.Warn != 2 {
+		t.Errorf("merged user = %+v", user)
+	}
+	if _, ok := db.Users["123456789@lid"]; ok {
+		t.Errorf("LID record kept after merge")
+	}
+	if _, ok := db.Users["987654321@lid"]; !ok {
+		t.Errorf("unknown LID record dropped")
+	}
+	if db.Stats.TotalUsers != int64(len(db.Users)) {
+		t.Errorf("TotalUsers = %d, want %d", db.Stats.TotalUsers, len(db.Users))
+	}
+
+	// Lookups by LID now land on the phone number record
+	if got := db.GetUser("123456789:5@lid"); got != user {
+		t.Errorf("GetUser by LID returned a different record")
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+	"zumygo/database"
+	"zumygo/helpers"
+	"zumygo/libs"
+)
+
+var identityOnce sync.Once
+
+// syncIdentities keys users by phone number through the LID store, and on
+// every connection merges records stored under a LID that is now known
+func syncIdentities(sock *libs.IClient) {
+	identityOnce.Do(func() {
+		database.SetPNResolver(sock.ResolvePN)
+	})
+
+	if database.DB == nil {
+		return
+	}
+	if merged := database.DB.MergeUsers(); merged > 0 {
+		helpers.Logger{}.Info(fmt.Sprintf("Merged %d user records into their phone number identity", merged))
+		if err := database.DB.ForceSave(); err != nil {
+			helpers.Logger{}.Error(fmt.Sprintf("Failed to save merged users: %v", err))
+		}
+	}
+}
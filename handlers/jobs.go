@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"zumygo/database"
+	"zumygo/helpers"
+	"zumygo/libs"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+var jobsOnce sync.Once
+
+// startJobs runs the job scheduler once the first connection is up
+func startJobs(sock *libs.IClient) {
+	jobsOnce.Do(func() {
+		go libs.RunJobs(func(job database.Job) {
+			runJob(sock, job)
+		})
+	})
+}
+
+// runJob sends a scheduled message, or runs a scheduled command through the
+// pipeline as if its creator had typed it in the target chat
+func runJob(sock *libs.IClient, job database.Job) {
+	chat, err := types.ParseJID(job.Chat)
+	if err != nil {
+		helpers.Logger{}.Error(fmt.Sprintf("Job %s has an invalid chat %q: %v", job.ID, job.Chat, err))
+		return
+	}
+
+	if job.Kind != libs.JobCommand {
+		if _, err := sock.Message(chat).Text(job.Text).Send(context.Background()); err != nil {
+			helpers.Logger{}.Error(fmt.Sprintf("Job %s failed to send: %v", job.ID, err))
+		}
+		return
+	}
+
+	sender, err := types.ParseJID(job.Sender)
+	if err != nil {
+		helpers.Logger{}.Error(fmt.Sprintf("Job %s has an invalid sender %q: %v", job.ID, job.Sender, err))
+		return
+	}
+
+	ProcessEvent(sock, &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:    chat,
+				Sender:  sender,
+				IsGroup: chat.Server == types.GroupServer,
+			},
+			ID:        types.MessageID(fmt.Sprintf("JOB%s%d", job.ID, time.Now().Unix())),
+			Type:      "text",
+			Timestamp: time.Now(),
+		},
+		Message: &waE2E.Message{Conversation: proto.String(job.Text)},
+	})
+}
@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"zumygo/config"
+	"zumygo/libs"
+	"zumygo/testkit"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDownloadMedia(t *testing.T) {
+	h := testkit.New(t)
+	h.Fake.PutMedia("/fake/image/1", []byte("image bytes"))
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ImageMessage: &waE2E.ImageMessage{
+			DirectPath: proto.String("/fake/image/1"),
+			Mimetype:   proto.String("image/jpeg"),
+		},
+	}))
+
+	media, err := m.Download(context.Background())
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if string(media.Data) != "image bytes" || media.Ext != ".jpg" || media.Kind != "image" {
+		t.Errorf("media = %q %q %q", media.Data, media.Ext, media.Kind)
+	}
+
+	path, err := m.DownloadTo(t.TempDir())
+	if err != nil {
+		t.Fatalf("DownloadTo: %v", err)
+	}
+	if filepath.Ext(path) != ".jpg" {
+		t.Errorf("DownloadTo path = %q, want a .jpg name", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "image bytes" {
+		t.Errorf("saved file = %q", data)
+	}
+
+	if _, err := h.Private(h.User, "no media").Download(context.Background()); !errors.Is(err, libs.ErrNoMedia) {
+		t.Errorf("Download without media = %v, want ErrNoMedia", err)
+	}
+}
+
+func TestDownloadMediaTooLarge(t *testing.T) {
+	h := testkit.New(t)
+	config.Config.MaxDownloadSize = 1
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		VideoMessage: &waE2E.VideoMessage{
+			DirectPath: proto.String("/fake/video/1"),
+			FileLength: proto.Uint64(2 << 20),
+		},
+	}))
+
+	if _, err := m.Download(context.Background()); !errors.Is(err, libs.ErrMediaTooLarge) {
+		t.Errorf("Download = %v, want ErrMediaTooLarge", err)
+	}
+}
+
+func TestDownloadQuotedRetry(t *testing.T) {
+	h := testkit.New(t)
+	h.Fake.PutMedia("/fake/document/new", []byte("%PDF-1.4"))
+	h.Fake.SetMediaRetry("QUOTED3", "/fake/document/new")
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String("save this"),
+			ContextInfo: &waE2E.ContextInfo{
+				StanzaID: proto.String("QUOTED3"),
+				QuotedMessage: &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+					DirectPath: proto.String("/fake/document/expired"),
+					FileName:   proto.String("report.pdf"),
+				}},
+			},
+		},
+	}))
+
+	media, err := m.DownloadQuoted(context.Background())
+	if err != nil {
+		t.Fatalf("DownloadQuoted: %v", err)
+	}
+	if string(media.Data) != "%PDF-1.4" || media.Ext != ".pdf" || media.FileName != "report.pdf" {
+		t.Errorf("media = %q %q %q", media.Data, media.Ext, media.FileName)
+	}
+}
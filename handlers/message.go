@@ -3,10 +3,10 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"zumygo/libs"
 	"zumygo/config"
-	"regexp"
-	"strings"
+	"zumygo/database"
+	"zumygo/helpers"
+	"zumygo/libs"
 	"time"
 	"sync"
 
@@ -24,14 +24,10 @@ type IHandler struct {
 
 // Performance optimizations
 var (
-	commandCache     = make(map[string]*regexp.Regexp)
-	commandCacheMutex sync.RWMutex
-	messageQueue     = make(chan *libs.IMessage, 2000) // Increased buffer for better throughput
-	workerCount      = 10 // Increased from 5 to 10 for better concurrency
+	scheduler        *messageScheduler
 	processingStats  = struct {
 		sync.RWMutex
 		processed int64
-		cached    int64
 		errors    int64
 	}{}
 )
@@ -54,17 +50,25 @@ func NewHandler(container *sqlstore.Container) *IHandler {
 
 // startMessageWorkers starts worker goroutines for concurrent message processing
 func startMessageWorkers() {
-	for i := 0; i < workerCount; i++ {
-		go func(workerID int) {
-			for msg := range messageQueue {
-				processMessage(msg, workerID)
-			}
-		}(i)
+	workers, size := 10, 2000
+	if cfg := config.Config; cfg != nil {
+		if cfg.Workers > 0 {
+			workers = cfg.Workers
+		}
+		if cfg.QueueSize > 0 {
+			size = cfg.QueueSize
+		}
+	}
+	
+	scheduler = newMessageScheduler(size)
+	for i := 0; i < workers; i++ {
+		go scheduler.run(i)
 	}
 }
 
-// processMessage processes a single message
-func processMessage(m *libs.IMessage, workerID int) {
+// processMessage processes a single message; release is called once the
+// message no longer needs to hold back later messages of its chat
+func processMessage(m *libs.IMessage, workerID int, release func()) {
 	// Add recovery mechanism for message processing
 	defer func() {
 		if r := recover(); r != nil {
@@ -75,16 +79,22 @@ func processMessage(m *libs.IMessage, workerID int) {
 		}
 	}()
 	
-	// Process the message
-	if m.Command != "" && libs.HasCommand(m.Command) {
-		start := time.Now()
-		// Get the client from the message's client field
-		var client *libs.IClient
-		if m.Client != nil {
-			client = m.Client
-		}
-		ExecuteCommand(client, m)
+	start := time.Now()
+	
+	// Run the message through the middleware chain
+	libs.Dispatch(m.Client, m, func(c *libs.IClient, m *libs.IMessage) {
+		release()
 		
+		// Commands waiting on the user's answers give their worker back, so
+		// idle sessions cannot starve everyone else
+		if workerID >= 0 && m.Cmd != nil && m.Cmd.IsSession {
+			libs.Background(func() { ExecuteCommand(c, m) })
+			return
+		}
+		ExecuteCommand(c, m)
+	})
+	
+	if m.Cmd != nil {
 		// Update processing stats
 		processingStats.Lock()
 		processingStats.processed++
@@ -109,42 +119,66 @@ func (h *IHandler) RegisterHandler(conn *whatsmeow.Client) func(evt interface{})
 		sock := libs.SerializeClient(conn)
 		switch v := evt.(type) {
 		case *events.Message:
-			m := libs.SerializeMessage(v, sock)
-
-			// skip deleted message
-			if m.Message.GetProtocolMessage() != nil && m.Message.GetProtocolMessage().GetType() == 0 {
+			m := receiveMessage(sock, v)
+			if m == nil {
 				return
 			}
 
 			// log (use async logging for better performance)
-			if m.Body != "" {
+			if m.Body != "" || m.Kind != helpers.KindUnknown {
 				go func() {
 					fmt.Println("\x1b[94mFrom :", v.Info.PushName, m.Info.Sender.User, "\x1b[39m")
 					if libs.HasCommand(m.Command) {
 						fmt.Println("\x1b[93mCommand :", m.Command, "\x1b[39m")
 					}
-					if len(m.Body) < 350 {
-						fmt.Print("\x1b[92mMessage : ", m.Body, "\x1b[39m", "\n")
-					} else {
-						fmt.Print("\x1b[92mMessage : ", m.Info.Type, "\x1b[39m", "\n")
-					}
+					fmt.Print("\x1b[92mMessage : ", describeMessage(m), "\x1b[39m", "\n")
 				}()
 			}
 
-			// Get command and queue for processing
-			if m.Command != "" && libs.HasCommand(m.Command) {
-				// Send to message queue for concurrent processing
-				select {
-				case messageQueue <- m:
-					// Message queued successfully
-				default:
-					// Queue is full, process immediately
-					go ExecuteCommand(sock, m)
+			// Answers to a waiting command skip the queue, since the command
+			// they belong to may be holding a worker
+			if libs.HasSession(m) {
+				go processMessage(m, -1, func() {})
+				return
+			}
+
+			// Send to message queue for fair, ordered processing
+			if !scheduler.Enqueue(m) {
+				helpers.GetPerformanceMonitor().IncrementQueueDropped()
+				if libs.HasCommand(m.Command) {
+					m.Reply(m.T("cmd.busy"))
 				}
 			}
 			return
 
+		case *events.GroupInfo:
+			emitGroupParticipants(sock, v)
+
+		case *events.MediaRetry:
+			libs.DeliverMediaRetry(v)
+
+		case *events.Receipt:
+			libs.Emit(sock, &libs.IEvent{
+				Type:   libs.EventReceipt,
+				Chat:   v.Chat,
+				Sender: v.Sender,
+				Raw:    v,
+			})
+
+		case *events.CallOffer:
+			libs.Emit(sock, &libs.IEvent{
+				Type:   libs.EventCall,
+				Chat:   v.From,
+				Sender: v.CallCreator,
+				Raw:    v,
+			})
+
 		case *events.Connected, *events.PushNameSetting:
+			if _, ok := v.(*events.Connected); ok {
+				syncIdentities(sock)
+				libs.Emit(sock, &libs.IEvent{Type: libs.EventConnected, Raw: v})
+				startJobs(sock)
+			}
 			if len(conn.Store.PushName) == 0 {
 				return
 			}
@@ -153,191 +187,166 @@ func (h *IHandler) RegisterHandler(conn *whatsmeow.Client) func(evt interface{})
 	}
 }
 
-// getCachedRegex returns a cached compiled regex or compiles and caches it
-func getCachedRegex(pattern string) *regexp.Regexp {
-	commandCacheMutex.RLock()
-	if re, exists := commandCache[pattern]; exists {
-		commandCacheMutex.RUnlock()
-		processingStats.Lock()
-		processingStats.cached++
-		processingStats.Unlock()
-		return re
+// describeMessage renders a message for the console log: its text, prefixed
+// with the kind when it is not plain text, or the kind alone when long
+func describeMessage(m *libs.IMessage) string {
+	if len(m.Body) >= 350 || (m.Body == "" && m.Kind != helpers.KindText) {
+		return "[" + m.Kind + "]"
 	}
-	commandCacheMutex.RUnlock()
-	
-	// Compile and cache the regex
-	commandCacheMutex.Lock()
-	defer commandCacheMutex.Unlock()
-	
-	// Double-check after acquiring write lock
-	if re, exists := commandCache[pattern]; exists {
-		return re
+	if m.Kind == helpers.KindText {
+		return m.Body
 	}
-	
-	var compiled *regexp.Regexp
-	if strings.ContainsAny(pattern, "|*+?()[]{}") {
-		// Use as regex pattern
-		compiled = regexp.MustCompile(`^` + pattern + `$`)
-	} else {
-		// Use exact match with quote meta for safety
-		compiled = regexp.MustCompile(`^` + regexp.QuoteMeta(pattern) + `$`)
+	return "[" + m.Kind + "] " + m.Body
+}
+
+// receiveMessage serializes an incoming message and publishes it to passive
+// listeners; it returns nil for messages that must not be processed
+func receiveMessage(sock *libs.IClient, v *events.Message) *libs.IMessage {
+	// deletions serialize to an empty message, so detect them first
+	revoked, isRevoke := libs.RevokedID(v.Message)
+	m := libs.SerializeMessage(v, sock)
+
+	// repost deleted message when anti-delete is on, off the event loop since
+	// it may upload media, then skip it
+	if isRevoke {
+		if database.DB != nil {
+			m.ChatData = database.DB.GetChat(m.Info.Chat.String())
+		}
+		libs.Background(func() { libs.RepostDeleted(sock, m, revoked) })
+		return nil
 	}
-	
-	commandCache[pattern] = compiled
-	
-	// Cleanup cache if it gets too large
-	if len(commandCache) > 2000 { // Increased from 1000
-		go cleanupCommandCache()
+	libs.RememberMessage(m)
+
+	// Publish to passive listeners, independent of command matching
+	libs.Emit(sock, &libs.IEvent{
+		Type:    libs.EventMessage,
+		Message: m,
+		Chat:    m.Info.Chat,
+		Sender:  m.Sender,
+		Raw:     v,
+	})
+	if m.Info.Chat == types.StatusBroadcastJID {
+		libs.Emit(sock, &libs.IEvent{
+			Type:    libs.EventStatus,
+			Message: m,
+			Chat:    m.Info.Chat,
+			Sender:  m.Sender,
+			Raw:     v,
+		})
 	}
-	
-	return compiled
+
+	return m
+}
+
+// ProcessEvent runs an incoming message through the dispatch pipeline on the
+// calling goroutine, bypassing the queue, and returns the serialized message.
+// It is meant for tests driving the bot with a fake messenger.
+func ProcessEvent(sock *libs.IClient, v *events.Message) *libs.IMessage {
+	m := receiveMessage(sock, v)
+	if m == nil {
+		return nil
+	}
+
+	processMessage(m, -1, func() {})
+	return m
 }
 
-// cleanupCommandCache removes old cache entries to prevent memory leaks
-func cleanupCommandCache() {
-	commandCacheMutex.Lock()
-	defer commandCacheMutex.Unlock()
+// emitGroupParticipants publishes one event per participant change action
+func emitGroupParticipants(sock *libs.IClient, v *events.GroupInfo) {
+	var sender types.JID
+	if v.Sender != nil {
+		sender = *v.Sender
+	}
+	
+	changes := []struct {
+		action string
+		jids   []types.JID
+	}{
+		{libs.ActionJoin, v.Join},
+		{libs.ActionLeave, v.Leave},
+		{libs.ActionPromote, v.Promote},
+		{libs.ActionDemote, v.Demote},
+	}
 	
-	// Keep only the most recent 1500 patterns (increased from 1000)
-	if len(commandCache) > 1500 {
-		// Simple cleanup: clear all and let them be recompiled as needed
-		// This is faster than selective cleanup for large caches
-		commandCache = make(map[string]*regexp.Regexp)
-		fmt.Printf("Command cache cleared, size was: %d\n", len(commandCache))
+	for _, change := range changes {
+		if len(change.jids) == 0 {
+			continue
+		}
+		libs.Emit(sock, &libs.IEvent{
+			Type:         libs.EventGroupParticipants,
+			Chat:         v.JID,
+			Sender:       sender,
+			Action:       change.action,
+			Participants: change.jids,
+			Raw:          v,
+		})
 	}
 }
 
+// ExecuteCommand is the final pipeline step, running the resolved command
 func ExecuteCommand(c *libs.IClient, m *libs.IMessage) {
 	// Add recovery mechanism for command execution
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Printf("Recovered from command execution panic: %v\n", r)
 			if m != nil {
-				m.Reply("An error occurred while executing the command")
+				m.Reply(m.T("cmd.exec_error"))
 			}
 		}
 	}()
 
-	// Get the command name (already processed in SerializeMessage)
-	commandName := m.Command
-	if commandName == "" {
+	cmd := m.Cmd
+	if cmd == nil || cmd.Execute == nil {
 		return
 	}
 	
-	// Extract prefix from the original message body
-	prefix, hasPrefix := libs.ExtractPrefix(m.Body)
-	if !hasPrefix {
-		return
+	// Show wait indicator
+	if cmd.IsWait {
+		m.React("⏳")
 	}
+
+	// Execute command bound to its timeout, shutdown and user cancellation
+	ctx, finish := libs.BeginCommand(cmd, m)
+	defer finish()
 	
-	lists := libs.GetList()
+	done := make(chan bool, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("Recovered from command execution panic: %v\n", r)
+				done <- false
+			}
+		}()
+		done <- cmd.Execute(ctx, c, m)
+	}()
 	
-	// Use a more efficient loop with early exit and optimized matching
-	for _, cmd := range lists {
-		// Execute Before hook if exists
-		if cmd.Before != nil {
-			cmd.Before(c, m)
+	// Wait for command completion with timeout
+	select {
+	case ok := <-done:
+		// Handle wait indicator
+		if cmd.IsWait && !ok {
+			m.React("❌")
 		}
-		
-		// Get cached regex for command matching
-		re := getCachedRegex(cmd.Name)
-		
-		if valid := len(re.FindAllString(commandName, -1)) > 0; valid {
-			if cmd.Execute != nil {
-				// Check public mode
-				if !config.Config.PublicMode && !m.IsOwner {
-					return
-				}
-
-				// Check prefix requirements
-				var cmdWithPref bool
-				var cmdWithoutPref bool
-				if cmd.IsPrefix && prefix != "" {
-					cmdWithPref = true
-				} else {
-					cmdWithPref = false
-				}
-
-				if !cmd.IsPrefix {
-					cmdWithoutPref = true
-				} else {
-					cmdWithoutPref = false
-				}
-
-				if !cmdWithPref && !cmdWithoutPref {
-					continue
-				}
-
-				// Check owner requirement
-				if cmd.IsOwner && !m.IsOwner {
-					continue
-				}
-
-				// Check query requirement
-				if cmd.IsQuery && m.Text == "" {
-					m.Reply("Query Required")
-					continue
-				}
-
-				// Check group requirement
-				if cmd.IsGroup && !m.Info.IsGroup {
-					m.Reply("Commands only work in Group Chat")
-					continue
-				}
-
-				// Check private requirement
-				if cmd.IsPrivate && m.Info.IsGroup {
-					m.Reply("Commands only work in Private Chat")
-					continue
-				}
-
-				// Check media requirement
-				if cmd.IsMedia && m.IsMedia == "" {
-					m.Reply("Reply to Media Message, or send Media with Command")
-					continue
-				}
-
-				// Show wait indicator
-				if cmd.IsWait {
-					m.React("⏳")
-				}
-
-				// Execute command with timeout protection
-				done := make(chan bool, 1)
-				go func() {
-					ok := cmd.Execute(c, m)
-					done <- ok
-				}()
-				
-				// Wait for command completion with timeout
-				select {
-				case ok := <-done:
-					// Handle wait indicator
-					if cmd.IsWait && !ok {
-						m.React("❌")
-					}
 
-					if cmd.IsWait && ok {
-						if c != nil && c.WA != nil {
-							c.WA.MarkRead([]string{m.Info.ID}, time.Now(), m.Info.Chat, m.Info.Sender)
-						}
-						m.React("")
-					}
-				case <-time.After(60 * time.Second): // 60 second timeout for commands
-					fmt.Printf("Command timeout: %s\n", commandName)
-					m.React("⏰")
-					return
-				}
-				
-				// Return after executing command to avoid multiple executions
-				return
+		if cmd.IsWait && ok {
+			if c != nil && c.Messenger != nil {
+				c.Messenger.MarkRead([]string{m.Info.ID}, time.Now(), m.Info.Chat, m.Info.Sender)
 			}
+			m.React("")
+		}
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			fmt.Printf("Command timeout: %s\n", m.Command)
+			m.React("⏰")
+		} else {
+			fmt.Printf("Command cancelled: %s\n", m.Command)
+			m.React("🚫")
 		}
 	}
 	
-	// Cleanup cache periodically (reduced frequency for better performance)
-	if time.Now().Unix()%2000 == 0 { // Every 2000th command instead of 1000th
-		go cleanupCommandCache()
+	// Update command statistics
+	if database.DB != nil {
+		database.DB.IncrementCommand(m.Command)
 	}
+	helpers.GetPerformanceMonitor().IncrementCommandCount()
 }
-
@@ -0,0 +1,148 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"zumygo/libs"
+	"zumygo/testkit"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSerializeGroupContext(t *testing.T) {
+	h := testkit.New(t)
+	group := types.NewJID("120363000000000002", types.GroupServer)
+	target := types.NewJID("6283333333333", types.DefaultUserServer)
+	h.AddGroup(group, []types.JID{h.User, testkit.BotJID}, target)
+	h.Fake.Groups[group].Name = "Test Group"
+
+	m := h.Event(testkit.NewMessage(group, h.User, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String("hey @6283333333333"),
+			ContextInfo: &waE2E.ContextInfo{
+				MentionedJID:  []string{target.String()},
+				StanzaID:      proto.String("QUOTED1"),
+				Participant:   proto.String(target.String()),
+				QuotedMessage: &waE2E.Message{Conversation: proto.String("original text")},
+			},
+		},
+	}))
+
+	if len(m.Mentions) != 1 || m.Mentions[0] != target {
+		t.Errorf("Mentions = %v, want [%v]", m.Mentions, target)
+	}
+	if m.QuotedSender != target {
+		t.Errorf("QuotedSender = %v, want %v", m.QuotedSender, target)
+	}
+	if m.QuotedText != "original text" {
+		t.Errorf("QuotedText = %q", m.QuotedText)
+	}
+	if m.QuotedMedia != nil {
+		t.Errorf("QuotedMedia = %v, want nil for a text quote", m.QuotedMedia)
+	}
+	if m.PushName != "Tester" {
+		t.Errorf("PushName = %q", m.PushName)
+	}
+	// Plain chatter skips the metadata lookup; commands load the group context
+	if m.GroupName != "" || m.IsGroupAdmin {
+		t.Errorf("group context loaded for a plain message")
+	}
+
+	libs.NewCommands(&libs.ICommand{Name: "groupctx", Execute: func(context.Context, *libs.IClient, *libs.IMessage) bool { return true }})
+	m = h.Send(group, h.User, ".groupctx")
+	if m.GroupName != "Test Group" || !m.IsGroupAdmin || !m.IsBotAdmin {
+		t.Errorf("group context = %q admin=%v bot=%v", m.GroupName, m.IsGroupAdmin, m.IsBotAdmin)
+	}
+
+	m = h.Send(group, target, ".groupctx")
+	if m.IsGroupAdmin {
+		t.Errorf("member reported as admin")
+	}
+}
+
+func TestSerializeQuotedMedia(t *testing.T) {
+	h := testkit.New(t)
+
+	image := &waE2E.ImageMessage{DirectPath: proto.String("/fake/image/1"), Caption: proto.String("a photo")}
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String("look"),
+			ContextInfo: &waE2E.ContextInfo{
+				StanzaID:      proto.String("QUOTED2"),
+				QuotedMessage: &waE2E.Message{ImageMessage: image},
+			},
+		},
+	}))
+
+	if m.QuotedMedia == nil || m.QuotedMedia.GetDirectPath() != "/fake/image/1" {
+		t.Errorf("QuotedMedia = %v, want the quoted image", m.QuotedMedia)
+	}
+	if m.QuotedText != "a photo" {
+		t.Errorf("QuotedText = %q, want the caption", m.QuotedText)
+	}
+	if m.QuotedSender != h.User {
+		t.Errorf("QuotedSender = %v, want the private chat", m.QuotedSender)
+	}
+	if m.IsGroupAdmin || m.GroupName != "" {
+		t.Errorf("private message has group context")
+	}
+}
+
+func TestSerializeResolvesLIDSender(t *testing.T) {
+	h := testkit.New(t)
+	lid := types.NewJID("123456789", types.HiddenUserServer)
+	h.Fake.PutLIDMapping(lid, h.User)
+
+	evt := testkit.NewMessage(lid, lid, &waE2E.Message{Conversation: proto.String("hi")})
+	evt.Info.AddressingMode = types.AddressingModeLID
+	if m := h.Event(evt); m.Sender != h.User {
+		t.Errorf("Sender = %v, want %v from the LID store", m.Sender, h.User)
+	}
+
+	unknown := types.NewJID("555", types.HiddenUserServer)
+	if m := h.Event(testkit.NewMessage(unknown, unknown, &waE2E.Message{Conversation: proto.String("hi")})); m.Sender != unknown {
+		t.Errorf("Sender = %v, want the LID kept when unmapped", m.Sender)
+	}
+}
+
+func TestSerializeDefersGroupContext(t *testing.T) {
+	h := testkit.New(t)
+	group := types.NewJID("120363000000000004", types.GroupServer)
+	h.AddGroup(group, []types.JID{h.User})
+	h.Fake.Groups[group].Name = "Lazy Group"
+
+	// Serializing happens in the event handler and must not fetch metadata
+	m := libs.SerializeMessage(testkit.NewMessage(group, h.User, &waE2E.Message{Conversation: proto.String("chatter")}), h.Client)
+	if m.GroupName != "" || m.IsGroupAdmin {
+		t.Errorf("group context resolved while serializing")
+	}
+
+	m.LoadGroupContext()
+	if m.GroupName != "Lazy Group" || !m.IsGroupAdmin || m.IsBotAdmin {
+		t.Errorf("group context = %q admin=%v bot=%v", m.GroupName, m.IsGroupAdmin, m.IsBotAdmin)
+	}
+}
+
+func TestSerializeQuotedBotMessage(t *testing.T) {
+	h := testkit.New(t)
+
+	sent, err := h.Client.Message(h.User).Text("from the bot").Send(context.Background())
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	m := h.Event(testkit.NewMessage(h.User, h.User, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String("replying"),
+			ContextInfo: &waE2E.ContextInfo{
+				StanzaID:      proto.String(sent.ID),
+				QuotedMessage: &waE2E.Message{Conversation: proto.String("from the bot")},
+			},
+		},
+	}))
+	if m.QuotedSender != testkit.BotJID {
+		t.Errorf("QuotedSender = %v, want the bot %v", m.QuotedSender, testkit.BotJID)
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"zumygo/config"
+	"zumygo/database"
+	"zumygo/helpers"
+	"zumygo/libs"
+)
+
+// Built-in pipeline stages, registered before any other middleware
+func init() {
+	libs.Use(statsMiddleware)
+	libs.Use(contextMiddleware)
+	libs.Use(sessionMiddleware)
+	libs.Use(followUpMiddleware)
+	libs.Use(resolveMiddleware)
+	libs.Use(disabledMiddleware)
+	libs.Use(suggestMiddleware)
+	libs.Use(permissionMiddleware)
+	libs.Use(argsMiddleware)
+	libs.Use(cooldownMiddleware)
+}
+
+// statsMiddleware updates message counters for every incoming message
+func statsMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if database.DB != nil {
+		database.DB.IncrementMessages()
+	}
+	helpers.GetPerformanceMonitor().IncrementMessageCount()
+
+	next()
+}
+
+// contextMiddleware attaches the database user and chat to the message
+func contextMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if database.DB != nil {
+		m.User = database.DB.GetUser(m.Sender.ToNonAD().String())
+		m.ChatData = database.DB.GetChat(m.Info.Chat.String())
+
+		// Update chat activity
+		m.ChatData.LastActivity = time.Now().Unix()
+		m.ChatData.MessageCount++
+	}
+
+	next()
+}
+
+// sessionMiddleware hands the message to a command awaiting the sender's
+// answer, ending the pipeline when it is consumed
+func sessionMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if libs.DeliverSession(m) {
+		return
+	}
+
+	next()
+}
+
+// followUpMiddleware turns a numbered reply to a remembered result set into
+// the command invocation for the selected item
+func followUpMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if m.Command == "" {
+		libs.ApplyFollowUp(m)
+	}
+
+	next()
+}
+
+// resolveMiddleware looks up the command matching the message, and loads the
+// group subject and admin status once a command needs them, so plain group
+// chatter never waits on a metadata lookup
+func resolveMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if m.Command != "" {
+		if cmd, ok := libs.Resolve(m.Command); ok && cmd.Execute != nil {
+			m.Cmd = cmd
+		}
+	}
+	if m.Cmd != nil {
+		m.LoadGroupContext()
+	}
+
+	next()
+}
+
+// disabledMiddleware drops commands disabled globally or in the chat, so the
+// message continues through the pipeline as plain text
+func disabledMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if m.Cmd != nil && libs.IsCommandDisabled(m.Cmd, m.Info.Chat.String()) {
+		m.Cmd = nil
+	}
+
+	next()
+}
+
+// suggestMiddleware replies with the closest command name the sender may run
+// when a prefixed message matches no command, unless the chat turned
+// suggestions off or the bot is private to its owner
+func suggestMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	private := config.Config != nil && !config.Config.PublicMode && !m.IsOwner
+	if m.Cmd == nil && m.Prefix != "" && m.Command != "" && !private {
+		// Commands that exist but are disabled stay silent
+		if _, exists := libs.Resolve(m.Command); !exists && (m.ChatData == nil || !m.ChatData.NoSuggest) {
+			allowed := func(cmd *libs.ICommand) bool { return libs.CanUse(conn, cmd, m) }
+			if name, ok := libs.Suggest(m.Command, m.Info.Chat.String(), allowed); ok {
+				m.Reply(m.T("cmd.suggest", m.Prefix, name))
+			}
+		}
+	}
+
+	next()
+}
+
+// permissionMiddleware checks the resolved command requirements
+func permissionMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	cmd := m.Cmd
+	if cmd == nil {
+		next()
+		return
+	}
+
+	// Check public mode
+	if config.Config != nil && !config.Config.PublicMode && !m.IsOwner {
+		return
+	}
+
+	// Check role and bot admin requirements
+	if denial, ok := libs.CheckPermission(conn, cmd, m); !ok {
+		m.Reply(m.T(denial))
+		return
+	}
+
+	// Check query requirement
+	if cmd.IsQuery && m.Text == "" {
+		m.Reply(m.T("cmd.query_required"))
+		return
+	}
+
+	// Check group requirement
+	if cmd.IsGroup && !m.Info.IsGroup {
+		m.Reply(m.T("cmd.group_only"))
+		return
+	}
+
+	// Check private requirement
+	if cmd.IsPrivate && m.Info.IsGroup {
+		m.Reply(m.T("cmd.private_only"))
+		return
+	}
+
+	// Check media requirement
+	if cmd.IsMedia && m.IsMedia == "" {
+		m.Reply(m.T("cmd.media_required"))
+		return
+	}
+
+	next()
+}
+
+// argsMiddleware parses the declared command parameters and replies with
+// the generated usage when the input does not match
+func argsMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	cmd := m.Cmd
+	if cmd == nil || len(cmd.Args) == 0 {
+		next()
+		return
+	}
+
+	params, err := libs.ParseArgs(cmd.Args, conn, m)
+	if err != nil {
+		message := err.Error()
+		if argErr, ok := err.(*libs.ArgError); ok {
+			message = argErr.Localize(m.Lang())
+		}
+		m.Reply(fmt.Sprintf("❎ %s\n\n%s", message, libs.Usage(cmd, m.Prefix, m.Lang())))
+		return
+	}
+
+	m.Params = params
+	next()
+}
+
+// cooldownMiddleware enforces the command cooldowns and burst limits
+func cooldownMiddleware(conn *libs.IClient, m *libs.IMessage, next libs.NextFunc) {
+	if m.Cmd == nil {
+		next()
+		return
+	}
+
+	if wait, ok := libs.CheckCooldown(m.Cmd, m); !ok {
+		seconds := int(math.Ceil(wait.Seconds()))
+		m.Reply(m.T("cmd.cooldown", seconds, m.Prefix, m.Command))
+		return
+	}
+
+	next()
+}
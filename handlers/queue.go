@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+	"zumygo/helpers"
+	"zumygo/libs"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// queuedMessage is a message waiting for a worker
+type queuedMessage struct {
+	m        *libs.IMessage
+	enqueued time.Time
+	priority bool
+}
+
+// chatLane holds the pending messages of one chat. Only one message of a
+// chat is dispatched at a time, so chats keep their arrival order.
+type chatLane struct {
+	pending   []*queuedMessage
+	busy      bool
+	scheduled bool
+}
+
+// messageScheduler hands messages to a fixed pool of workers, round-robin
+// across chats, with owner commands scheduled ahead of everyone else
+type messageScheduler struct {
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	lanes    map[types.JID]*chatLane
+	ready    []types.JID
+	priority []types.JID
+	depth    int
+
+	// slots bounds how many messages may wait at once
+	slots chan struct{}
+}
+
+// newMessageScheduler creates a scheduler admitting up to size waiting
+// messages
+func newMessageScheduler(size int) *messageScheduler {
+	s := &messageScheduler{
+		lanes: make(map[types.JID]*chatLane),
+		slots: make(chan struct{}, size),
+	}
+	s.cond = sync.NewCond(&s.mutex)
+	return s
+}
+
+// Enqueue adds a message to its chat lane and reports whether it was
+// accepted. It runs on the WhatsApp event goroutine, so it never waits: a
+// message arriving while the queue is full is rejected, owner commands
+// included, which only skip ahead of the messages already waiting.
+func (s *messageScheduler) Enqueue(m *libs.IMessage) bool {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return false
+	}
+	priority := m.IsOwner && libs.HasCommand(m.Command)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	chat := m.Info.Chat
+	lane, exists := s.lanes[chat]
+	if !exists {
+		lane = &chatLane{}
+		s.lanes[chat] = lane
+	}
+	lane.pending = append(lane.pending, &queuedMessage{m: m, enqueued: time.Now(), priority: priority})
+	s.depth++
+	helpers.GetPerformanceMonitor().SetQueueDepth(s.depth)
+
+	if !lane.busy && !lane.scheduled {
+		s.schedule(chat, lane)
+	}
+	return true
+}
+
+// next blocks until a chat is ready and takes its oldest message
+func (s *messageScheduler) next() (types.JID, *queuedMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for len(s.priority) == 0 && len(s.ready) == 0 {
+		s.cond.Wait()
+	}
+
+	var chat types.JID
+	if len(s.priority) > 0 {
+		chat, s.priority = s.priority[0], s.priority[1:]
+	} else {
+		chat, s.ready = s.ready[0], s.ready[1:]
+	}
+
+	lane := s.lanes[chat]
+	item := lane.pending[0]
+	lane.pending[0] = nil
+	lane.pending = lane.pending[1:]
+	lane.busy = true
+	lane.scheduled = false
+
+	s.depth--
+	helpers.GetPerformanceMonitor().SetQueueDepth(s.depth)
+	<-s.slots
+	return chat, item
+}
+
+// release lets the next message of a chat be dispatched
+func (s *messageScheduler) release(chat types.JID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lane, exists := s.lanes[chat]
+	if !exists || !lane.busy {
+		return
+	}
+
+	lane.busy = false
+	if len(lane.pending) == 0 {
+		delete(s.lanes, chat)
+		return
+	}
+	s.schedule(chat, lane)
+}
+
+// schedule puts a chat at the back of the ready list matching its oldest
+// message; the caller holds the mutex
+func (s *messageScheduler) schedule(chat types.JID, lane *chatLane) {
+	lane.scheduled = true
+	if lane.pending[0].priority {
+		s.priority = append(s.priority, chat)
+	} else {
+		s.ready = append(s.ready, chat)
+	}
+	s.cond.Signal()
+}
+
+// run serves messages forever on the calling goroutine. The chat lane is
+// released once the command starts, so a long command does not hold back
+// later messages of its chat while still occupying this worker.
+func (s *messageScheduler) run(workerID int) {
+	for {
+		chat, item := s.next()
+		helpers.GetPerformanceMonitor().RecordQueueWait(time.Since(item.enqueued))
+
+		var once sync.Once
+		release := func() {
+			once.Do(func() { s.release(chat) })
+		}
+
+		processMessage(item.m, workerID, release)
+		release()
+	}
+}
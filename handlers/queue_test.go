@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+	"zumygo/libs"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// queueMessage builds a message from chat with the given body, which is
+// also its command name
+func queueMessage(chat string, body string, owner bool) *libs.IMessage {
+	jid := types.NewJID(chat, types.DefaultUserServer)
+	return &libs.IMessage{
+		Info:    types.MessageInfo{MessageSource: types.MessageSource{Chat: jid, Sender: jid}},
+		Sender:  jid,
+		IsOwner: owner,
+		Body:    body,
+		Command: body,
+	}
+}
+
+// drain takes n messages, releasing each chat right away, and returns their
+// bodies in dispatch order
+func drain(s *messageScheduler, n int) []string {
+	var bodies []string
+	for i := 0; i < n; i++ {
+		chat, item := s.next()
+		bodies = append(bodies, item.m.Body)
+		s.release(chat)
+	}
+	return bodies
+}
+
+func TestSchedulerKeepsChatOrder(t *testing.T) {
+	s := newMessageScheduler(10)
+	for _, body := range []string{"a1", "a2", "a3"} {
+		s.Enqueue(queueMessage("6281", body, false))
+	}
+
+	chat, item := s.next()
+	if item.m.Body != "a1" {
+		t.Fatalf("first = %q, want a1", item.m.Body)
+	}
+
+	// The chat stays busy until released, so a2 must wait
+	taken := make(chan string, 1)
+	go func() {
+		_, item := s.next()
+		taken <- item.m.Body
+	}()
+	select {
+	case body := <-taken:
+		t.Fatalf("%q dispatched while a1 was running", body)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	s.release(chat)
+	if body := <-taken; body != "a2" {
+		t.Errorf("second = %q, want a2", body)
+	}
+	s.release(chat)
+	if got := drain(s, 1); got[0] != "a3" {
+		t.Errorf("third = %q, want a3", got[0])
+	}
+}
+
+func TestSchedulerRoundRobin(t *testing.T) {
+	s := newMessageScheduler(10)
+	for _, msg := range []struct{ chat, body string }{
+		{"6281", "a1"}, {"6281", "a2"}, {"6281", "a3"}, {"6282", "b1"}, {"6283", "c1"}, {"6282", "b2"},
+	} {
+		s.Enqueue(queueMessage(msg.chat, msg.body, false))
+	}
+
+	want := []string{"a1", "b1", "c1", "a2", "b2", "a3"}
+	if got := drain(s, len(want)); !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestSchedulerOwnerFirst(t *testing.T) {
+	libs.NewCommands(&libs.ICommand{Name: "queueowner", Execute: func(context.Context, *libs.IClient, *libs.IMessage) bool { return true }})
+
+	s := newMessageScheduler(10)
+	s.Enqueue(queueMessage("6281", "a1", false))
+	s.Enqueue(queueMessage("6282", "b1", false))
+	s.Enqueue(queueMessage("6289", "chatter", true))
+	s.Enqueue(queueMessage("6288", "queueowner", true))
+
+	// Only owner commands skip ahead; owner chatter waits its turn
+	want := []string{"queueowner", "a1", "b1", "chatter"}
+	if got := drain(s, len(want)); !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestSchedulerBackpressure(t *testing.T) {
+	s := newMessageScheduler(2)
+	for _, body := range []string{"a1", "b1"} {
+		if !s.Enqueue(queueMessage("628"+body, body, false)) {
+			t.Fatalf("%s rejected below capacity", body)
+		}
+	}
+
+	// A full queue rejects right away instead of stalling the event loop
+	start := time.Now()
+	if s.Enqueue(queueMessage("6283", "c1", false)) {
+		t.Fatalf("c1 accepted over capacity")
+	}
+	if waited := time.Since(start); waited > 10*time.Millisecond {
+		t.Errorf("rejected after %v, want no wait", waited)
+	}
+
+	// Owner messages count against the bound too
+	if s.Enqueue(queueMessage("6289", "owner", true)) {
+		t.Errorf("owner message accepted over capacity")
+	}
+
+	drain(s, 1)
+	if !s.Enqueue(queueMessage("6283", "c1", false)) {
+		t.Errorf("c1 rejected after a slot was freed")
+	}
+}
+
+// sessionWaiting and sessionFinished steer the queuesession command; commands
+// register once per process, so they are swapped per test run
+var sessionWaiting, sessionFinished chan struct{}
+
+func TestSessionCommandFreesWorker(t *testing.T) {
+	sessionWaiting, sessionFinished = make(chan struct{}), make(chan struct{})
+	libs.NewCommands(&libs.ICommand{
+		Name:      "queuesession",
+		IsSession: true,
+		Execute: func(ctx context.Context, conn *libs.IClient, m *libs.IMessage) bool {
+			close(sessionWaiting)
+			<-sessionFinished
+			return true
+		},
+	})
+
+	session := queueMessage("6281", ".queuesession", false)
+	session.Command = "queuesession"
+
+	// The worker must return while the session command still waits
+	returned := make(chan struct{})
+	go func() {
+		processMessage(session, 0, func() {})
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatalf("worker held by a waiting session")
+	}
+
+	<-sessionWaiting
+	close(sessionFinished)
+	libs.WaitBackground()
+}
@@ -0,0 +1,220 @@
+package helpers
+
+import (
+	"strings"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// Message kinds reported by ExtractContent
+const (
+	KindText         = "text"
+	KindImage        = "image"
+	KindVideo        = "video"
+	KindPTV          = "ptv"
+	KindAudio        = "audio"
+	KindVoice        = "voice"
+	KindDocument     = "document"
+	KindSticker      = "sticker"
+	KindLocation     = "location"
+	KindLiveLocation = "live_location"
+	KindContact      = "contact"
+	KindContacts     = "contacts"
+	KindPoll         = "poll"
+	KindPollVote     = "poll_vote"
+	KindReaction     = "reaction"
+	KindEvent        = "event"
+	KindButtons      = "buttons"
+	KindList         = "list"
+	KindInteractive  = "interactive"
+	KindTemplate     = "template"
+	KindButtonReply  = "button_reply"
+	KindListReply    = "list_reply"
+	KindFlowReply    = "flow_reply"
+	KindGroupInvite  = "group_invite"
+	KindProduct      = "product"
+	KindOrder        = "order"
+	KindPayment      = "payment"
+	KindCall         = "call"
+	KindPin          = "pin"
+	KindRevoke       = "revoke"
+	KindProtocol     = "protocol"
+	KindUnknown      = "unknown"
+)
+
+// MessageContent is the readable text of a message and what kind it is
+type MessageContent struct {
+	Text   string
+	Kind   string
+	Edited bool // The message replaces an earlier one
+}
+
+// ExtractContent returns the text and kind of any message, looking through
+// ephemeral, view-once, edit and other wrappers. Media give their caption,
+// polls their question and options, locations and contacts their names,
+// and button or list replies the option chosen.
+func ExtractContent(msg *waE2E.Message) MessageContent {
+	if msg == nil {
+		return MessageContent{Kind: KindUnknown}
+	}
+
+	// Wrappers around another message
+	for _, wrapper := range []*waE2E.FutureProofMessage{
+		msg.GetEphemeralMessage(),
+		msg.GetViewOnceMessage(),
+		msg.GetViewOnceMessageV2(),
+		msg.GetViewOnceMessageV2Extension(),
+		msg.GetDocumentWithCaptionMessage(),
+		msg.GetGroupMentionedMessage(),
+		msg.GetBotInvokeMessage(),
+		msg.GetBotForwardedMessage(),
+		msg.GetLottieStickerMessage(),
+		msg.GetStatusMentionMessage(),
+		msg.GetGroupStatusMentionMessage(),
+		msg.GetGroupStatusMessage(),
+		msg.GetGroupStatusMessageV2(),
+		msg.GetAssociatedChildMessage(),
+		msg.GetPollCreationMessageV4(),
+		msg.GetPollCreationMessageV5(),
+		msg.GetQuestionMessage(),
+	} {
+		if inner := wrapper.GetMessage(); inner != nil {
+			return ExtractContent(inner)
+		}
+	}
+
+	if inner := msg.GetEditedMessage().GetMessage(); inner != nil {
+		return edited(ExtractContent(inner))
+	}
+	if protocol := msg.GetProtocolMessage(); protocol != nil {
+		switch {
+		case protocol.GetEditedMessage() != nil:
+			return edited(ExtractContent(protocol.GetEditedMessage()))
+		case protocol.GetType() == waE2E.ProtocolMessage_REVOKE:
+			return MessageContent{Kind: KindRevoke}
+		}
+		return MessageContent{Kind: KindProtocol}
+	}
+
+	switch {
+	case msg.Conversation != nil:
+		return MessageContent{Text: msg.GetConversation(), Kind: KindText}
+	case msg.ExtendedTextMessage != nil:
+		return MessageContent{Text: msg.GetExtendedTextMessage().GetText(), Kind: KindText}
+
+	case msg.ImageMessage != nil:
+		return MessageContent{Text: msg.GetImageMessage().GetCaption(), Kind: KindImage}
+	case msg.VideoMessage != nil:
+		return MessageContent{Text: msg.GetVideoMessage().GetCaption(), Kind: KindVideo}
+	case msg.PtvMessage != nil:
+		return MessageContent{Text: msg.GetPtvMessage().GetCaption(), Kind: KindPTV}
+	case msg.AudioMessage != nil:
+		if msg.GetAudioMessage().GetPTT() {
+			return MessageContent{Kind: KindVoice}
+		}
+		return MessageContent{Kind: KindAudio}
+	case msg.DocumentMessage != nil:
+		return MessageContent{Text: msg.GetDocumentMessage().GetCaption(), Kind: KindDocument}
+	case msg.StickerMessage != nil:
+		return MessageContent{Kind: KindSticker}
+
+	case msg.LocationMessage != nil:
+		location := msg.GetLocationMessage()
+		return MessageContent{Text: joinLines(location.GetName(), location.GetAddress(), location.GetComment()), Kind: KindLocation}
+	case msg.LiveLocationMessage != nil:
+		return MessageContent{Text: msg.GetLiveLocationMessage().GetCaption(), Kind: KindLiveLocation}
+	case msg.ContactMessage != nil:
+		return MessageContent{Text: msg.GetContactMessage().GetDisplayName(), Kind: KindContact}
+	case msg.ContactsArrayMessage != nil:
+		contacts := msg.GetContactsArrayMessage()
+		names := []string{contacts.GetDisplayName()}
+		for _, contact := range contacts.GetContacts() {
+			names = append(names, contact.GetDisplayName())
+		}
+		return MessageContent{Text: joinLines(names...), Kind: KindContacts}
+
+	case msg.PollCreationMessage != nil:
+		return pollContent(msg.GetPollCreationMessage())
+	case msg.PollCreationMessageV2 != nil:
+		return pollContent(msg.GetPollCreationMessageV2())
+	case msg.PollCreationMessageV3 != nil:
+		return pollContent(msg.GetPollCreationMessageV3())
+	case msg.PollUpdateMessage != nil:
+		return MessageContent{Kind: KindPollVote}
+	case msg.ReactionMessage != nil:
+		return MessageContent{Text: msg.GetReactionMessage().GetText(), Kind: KindReaction}
+	case msg.EncReactionMessage != nil:
+		return MessageContent{Kind: KindReaction}
+	case msg.EventMessage != nil:
+		event := msg.GetEventMessage()
+		return MessageContent{Text: joinLines(event.GetName(), event.GetDescription()), Kind: KindEvent}
+
+	case msg.ButtonsMessage != nil:
+		return MessageContent{Text: msg.GetButtonsMessage().GetContentText(), Kind: KindButtons}
+	case msg.ListMessage != nil:
+		list := msg.GetListMessage()
+		return MessageContent{Text: joinLines(list.GetTitle(), list.GetDescription()), Kind: KindList}
+	case msg.InteractiveMessage != nil:
+		return MessageContent{Text: msg.GetInteractiveMessage().GetBody().GetText(), Kind: KindInteractive}
+	case msg.TemplateMessage != nil:
+		return MessageContent{Text: msg.GetTemplateMessage().GetHydratedTemplate().GetHydratedContentText(), Kind: KindTemplate}
+	case msg.ButtonsResponseMessage != nil:
+		return MessageContent{Text: msg.GetButtonsResponseMessage().GetSelectedDisplayText(), Kind: KindButtonReply}
+	case msg.ListResponseMessage != nil:
+		return MessageContent{Text: msg.GetListResponseMessage().GetTitle(), Kind: KindListReply}
+	case msg.TemplateButtonReplyMessage != nil:
+		return MessageContent{Text: msg.GetTemplateButtonReplyMessage().GetSelectedDisplayText(), Kind: KindButtonReply}
+	case msg.InteractiveResponseMessage != nil:
+		return MessageContent{Text: msg.GetInteractiveResponseMessage().GetBody().GetText(), Kind: KindFlowReply}
+
+	case msg.GroupInviteMessage != nil:
+		invite := msg.GetGroupInviteMessage()
+		return MessageContent{Text: joinLines(invite.GetGroupName(), invite.GetCaption()), Kind: KindGroupInvite}
+	case msg.ProductMessage != nil:
+		product := msg.GetProductMessage().GetProduct()
+		return MessageContent{Text: joinLines(product.GetTitle(), product.GetDescription()), Kind: KindProduct}
+	case msg.OrderMessage != nil:
+		order := msg.GetOrderMessage()
+		return MessageContent{Text: joinLines(order.GetOrderTitle(), order.GetMessage()), Kind: KindOrder}
+	case msg.RequestPaymentMessage != nil:
+		return MessageContent{Text: ExtractContent(msg.GetRequestPaymentMessage().GetNoteMessage()).Text, Kind: KindPayment}
+	case msg.SendPaymentMessage != nil:
+		return MessageContent{Text: ExtractContent(msg.GetSendPaymentMessage().GetNoteMessage()).Text, Kind: KindPayment}
+	case msg.Call != nil, msg.ScheduledCallCreationMessage != nil, msg.BcallMessage != nil:
+		return MessageContent{Text: msg.GetScheduledCallCreationMessage().GetTitle(), Kind: KindCall}
+	case msg.PinInChatMessage != nil:
+		return MessageContent{Kind: KindPin}
+	case msg.SenderKeyDistributionMessage != nil, msg.KeepInChatMessage != nil:
+		return MessageContent{Kind: KindProtocol}
+	}
+
+	return MessageContent{Kind: KindUnknown}
+}
+
+// edited marks content as the new version of an edited message
+func edited(content MessageContent) MessageContent {
+	content.Edited = true
+	return content
+}
+
+// pollContent renders a poll as its question followed by its options
+func pollContent(poll *waE2E.PollCreationMessage) MessageContent {
+	lines := []string{poll.GetName()}
+	for _, option := range poll.GetOptions() {
+		if name := option.GetOptionName(); name != "" {
+			lines = append(lines, "- "+name)
+		}
+	}
+	return MessageContent{Text: joinLines(lines...), Kind: KindPoll}
+}
+
+// joinLines joins the non-empty parts with newlines
+func joinLines(parts ...string) string {
+	var lines []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			lines = append(lines, part)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
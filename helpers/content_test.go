@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExtractContent(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *waE2E.Message
+		want MessageContent
+	}{
+		{"nil", nil, MessageContent{Kind: KindUnknown}},
+		{"conversation", &waE2E.Message{Conversation: proto.String("hi")}, MessageContent{Text: "hi", Kind: KindText}},
+		{"extended text", &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{Text: proto.String("hi")}}, MessageContent{Text: "hi", Kind: KindText}},
+		{"image caption", &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String("photo")}}, MessageContent{Text: "photo", Kind: KindImage}},
+		{"voice note", &waE2E.Message{AudioMessage: &waE2E.AudioMessage{PTT: proto.Bool(true)}}, MessageContent{Kind: KindVoice}},
+		{"audio", &waE2E.Message{AudioMessage: &waE2E.AudioMessage{}}, MessageContent{Kind: KindAudio}},
+		{"sticker", &waE2E.Message{StickerMessage: &waE2E.StickerMessage{}}, MessageContent{Kind: KindSticker}},
+		{"document with caption", &waE2E.Message{DocumentWithCaptionMessage: &waE2E.FutureProofMessage{
+			Message: &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{Caption: proto.String(".ping")}},
+		}}, MessageContent{Text: ".ping", Kind: KindDocument}},
+		{"ephemeral view once", &waE2E.Message{EphemeralMessage: &waE2E.FutureProofMessage{
+			Message: &waE2E.Message{ViewOnceMessageV2: &waE2E.FutureProofMessage{
+				Message: &waE2E.Message{VideoMessage: &waE2E.VideoMessage{Caption: proto.String("clip")}},
+			}},
+		}}, MessageContent{Text: "clip", Kind: KindVideo}},
+		{"location", &waE2E.Message{LocationMessage: &waE2E.LocationMessage{
+			Name: proto.String("Monas"), Address: proto.String("Jakarta"),
+		}}, MessageContent{Text: "Monas\nJakarta", Kind: KindLocation}},
+		{"contacts", &waE2E.Message{ContactsArrayMessage: &waE2E.ContactsArrayMessage{
+			Contacts: []*waE2E.ContactMessage{{DisplayName: proto.String("Ana")}, {DisplayName: proto.String("Budi")}},
+		}}, MessageContent{Text: "Ana\nBudi", Kind: KindContacts}},
+		{"poll", &waE2E.Message{PollCreationMessageV3: &waE2E.PollCreationMessage{
+			Name:    proto.String("Lunch?"),
+			Options: []*waE2E.PollCreationMessage_Option{{OptionName: proto.String("Yes")}, {OptionName: proto.String("No")}},
+		}}, MessageContent{Text: "Lunch?\n- Yes\n- No", Kind: KindPoll}},
+		{"reaction", &waE2E.Message{ReactionMessage: &waE2E.ReactionMessage{Text: proto.String("👍")}}, MessageContent{Text: "👍", Kind: KindReaction}},
+		{"list reply", &waE2E.Message{ListResponseMessage: &waE2E.ListResponseMessage{Title: proto.String("Group")}}, MessageContent{Text: "Group", Kind: KindListReply}},
+		{"button reply", &waE2E.Message{ButtonsResponseMessage: &waE2E.ButtonsResponseMessage{
+			Response: &waE2E.ButtonsResponseMessage_SelectedDisplayText{SelectedDisplayText: "Yes"},
+		}}, MessageContent{Text: "Yes", Kind: KindButtonReply}},
+		{"edit", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			EditedMessage: &waE2E.Message{Conversation: proto.String("fixed")},
+		}}, MessageContent{Text: "fixed", Kind: KindText, Edited: true}},
+		{"revoke", &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+		}}, MessageContent{Kind: KindRevoke}},
+		{"empty", &waE2E.Message{}, MessageContent{Kind: KindUnknown}},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractContent(tt.msg); got != tt.want {
+			t.Errorf("%s: ExtractContent = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
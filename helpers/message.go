@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"encoding/json"
+
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types/events"
@@ -15,30 +17,33 @@ func ParseMessage(message *events.Message) *waE2E.Message {
 		return message.Message.GetViewOnceMessageV2().GetMessage()
 	} else if message.Message.GetViewOnceMessageV2Extension() != nil {
 		return message.Message.GetViewOnceMessageV2Extension().GetMessage()
+	} else if message.Message.GetDocumentWithCaptionMessage() != nil {
+		return message.Message.GetDocumentWithCaptionMessage().GetMessage()
 	} else if message.Message.GetProtocolMessage() != nil {
 		return message.Message.GetProtocolMessage().GetEditedMessage()
 	}
 	return message.Message
 }
 
-func GetTextMessage(message *events.Message) string {
-	var msg *waE2E.Message
-
-	msg = ParseMessage(message)
-
-	if val := msg.GetConversation(); val != "" {
-		return val
-	} else if val := msg.GetExtendedTextMessage().GetText(); val != "" {
-		return val
-	} else if val := msg.GetImageMessage().GetCaption(); val != "" {
-		return val
-	} else if val := msg.GetVideoMessage().GetCaption(); val != "" {
-		return val
-	} else if val := msg.GetPtvMessage().GetCaption(); val != "" {
-		return val
+// IsViewOnce reports whether a message is view-once media, wrapped or
+// flagged on the media itself
+func IsViewOnce(msg *waE2E.Message) bool {
+	if inner := msg.GetEphemeralMessage().GetMessage(); inner != nil {
+		return IsViewOnce(inner)
 	}
+	if msg.GetViewOnceMessage() != nil || msg.GetViewOnceMessageV2() != nil || msg.GetViewOnceMessageV2Extension() != nil {
+		return true
+	}
+	return msg.GetImageMessage().GetViewOnce() || msg.GetVideoMessage().GetViewOnce() || msg.GetAudioMessage().GetViewOnce()
+}
 
-	return ""
+func GetTextMessage(message *events.Message) string {
+	return GetMessageText(ParseMessage(message))
+}
+
+// GetMessageText returns the readable text of a message, see ExtractContent
+func GetMessageText(msg *waE2E.Message) string {
+	return ExtractContent(msg).Text
 }
 
 func ParseQuotedMessage(message *waE2E.Message) *waE2E.Message {
@@ -66,6 +71,16 @@ func ParseQuotedMessage(message *waE2E.Message) *waE2E.Message {
 		return msg.GetQuotedMessage()
 	} else if msg := message.GetContactMessage().GetContextInfo(); msg != nil {
 		return msg.GetQuotedMessage()
+	} else if msg := message.GetButtonsResponseMessage().GetContextInfo(); msg != nil {
+		return msg.GetQuotedMessage()
+	} else if msg := message.GetListResponseMessage().GetContextInfo(); msg != nil {
+		return msg.GetQuotedMessage()
+	} else if msg := message.GetTemplateButtonReplyMessage().GetContextInfo(); msg != nil {
+		return msg.GetQuotedMessage()
+	} else if msg := message.GetInteractiveResponseMessage().GetContextInfo(); msg != nil {
+		return msg.GetQuotedMessage()
+	} else if msg := message.GetInteractiveMessage().GetContextInfo(); msg != nil {
+		return msg.GetQuotedMessage()
 	}
 	return nil
 }
@@ -95,6 +110,16 @@ func GetContextInfo(message *waE2E.Message) *waE2E.ContextInfo {
 		return msg
 	} else if msg := message.GetContactMessage().GetContextInfo(); msg != nil {
 		return msg
+	} else if msg := message.GetButtonsResponseMessage().GetContextInfo(); msg != nil {
+		return msg
+	} else if msg := message.GetListResponseMessage().GetContextInfo(); msg != nil {
+		return msg
+	} else if msg := message.GetTemplateButtonReplyMessage().GetContextInfo(); msg != nil {
+		return msg
+	} else if msg := message.GetInteractiveResponseMessage().GetContextInfo(); msg != nil {
+		return msg
+	} else if msg := message.GetInteractiveMessage().GetContextInfo(); msg != nil {
+		return msg
 	}
 	return nil
 }
@@ -127,4 +152,24 @@ func GetMediaType(message *waE2E.Message) string {
 		return "sticker"
 	}
 	return ""
-}
\ No newline at end of file
+}
+
+// GetSelectedID returns the ID of the button, list row or native flow option
+// a response message selects
+func GetSelectedID(message *waE2E.Message) string {
+	if val := message.GetButtonsResponseMessage().GetSelectedButtonID(); val != "" {
+		return val
+	} else if val := message.GetListResponseMessage().GetSingleSelectReply().GetSelectedRowID(); val != "" {
+		return val
+	} else if val := message.GetTemplateButtonReplyMessage().GetSelectedID(); val != "" {
+		return val
+	} else if flow := message.GetInteractiveResponseMessage().GetNativeFlowResponseMessage(); flow != nil {
+		var params struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal([]byte(flow.GetParamsJSON()), &params) == nil {
+			return params.ID
+		}
+	}
+	return ""
+}
@@ -18,6 +18,13 @@ type PerformanceMonitor struct {
 	dbOperations  int64
 	httpRequests  int64
 	
+	// Message queue
+	queueDepth    int64
+	queueDropped  int64
+	queueWaited   int64
+	queueWaitSum  time.Duration
+	queueWaitMax  time.Duration
+	
 	mutex sync.RWMutex
 }
 
@@ -85,6 +92,31 @@ func (pm *PerformanceMonitor) IncrementHTTPRequest() {
 	pm.httpRequests++
 }
 
+// SetQueueDepth records how many messages are waiting to be processed
+func (pm *PerformanceMonitor) SetQueueDepth(depth int) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	pm.queueDepth = int64(depth)
+}
+
+// RecordQueueWait records how long a message waited before processing
+func (pm *PerformanceMonitor) RecordQueueWait(wait time.Duration) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	pm.queueWaited++
+	pm.queueWaitSum += wait
+	if wait > pm.queueWaitMax {
+		pm.queueWaitMax = wait
+	}
+}
+
+// IncrementQueueDropped increments the counter of messages rejected by a full queue
+func (pm *PerformanceMonitor) IncrementQueueDropped() {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	pm.queueDropped++
+}
+
 // GetStats returns current performance statistics
 func (pm *PerformanceMonitor) GetStats() map[string]interface{} {
 	pm.mutex.RLock()
@@ -110,6 +142,12 @@ func (pm *PerformanceMonitor) GetStats() map[string]interface{} {
 	dbOpsPerMinute := float64(pm.dbOperations) / minutes
 	httpPerMinute := float64(pm.httpRequests) / minutes
 	
+	// Average queue wait
+	var queueWaitAvg time.Duration
+	if pm.queueWaited > 0 {
+		queueWaitAvg = pm.queueWaitSum / time.Duration(pm.queueWaited)
+	}
+	
 	return map[string]interface{}{
 		"uptime":              uptime.String(),
 		"messages_total":      pm.messageCount,
@@ -131,6 +169,10 @@ func (pm *PerformanceMonitor) GetStats() map[string]interface{} {
 		"memory_heap_sys":     formatBytes(m.HeapSys),
 		"goroutines":          runtime.NumGoroutine(),
 		"gc_count":            m.NumGC,
+		"queue_depth":         pm.queueDepth,
+		"queue_dropped":       pm.queueDropped,
+		"queue_wait_avg":      queueWaitAvg.Round(time.Millisecond).String(),
+		"queue_wait_max":      pm.queueWaitMax.Round(time.Millisecond).String(),
 	}
 }
 
@@ -142,15 +184,16 @@ func (pm *PerformanceMonitor) GetPerformanceReport() string {
 	report += "═══════════════════════\n\n"
 	
 	report += fmt.Sprintf("⏱️  Uptime: %s\n", stats["uptime"])
-	report += fmt.Sprintf("📨 Messages: %d (%.2f/min)\n", stats["messages_total"], stats["messages_per_minute"])
-	report += fmt.Sprintf("⚡ Commands: %d (%.2f/min)\n", stats["commands_total"], stats["commands_per_minute"])
-	report += fmt.Sprintf("❌ Errors: %d (%.2f/min)\n", stats["errors_total"], stats["errors_per_minute"])
-	report += fmt.Sprintf("💾 DB Operations: %d (%.2f/min)\n", stats["db_operations"], stats["db_ops_per_minute"])
-	report += fmt.Sprintf("🌐 HTTP Requests: %d (%.2f/min)\n", stats["http_requests"], stats["http_per_minute"])
+	report += fmt.Sprintf("📨 Messages: %d (%s/min)\n", stats["messages_total"], stats["messages_per_minute"])
+	report += fmt.Sprintf("⚡ Commands: %d (%s/min)\n", stats["commands_total"], stats["commands_per_minute"])
+	report += fmt.Sprintf("❌ Errors: %d (%s/min)\n", stats["errors_total"], stats["errors_per_minute"])
+	report += fmt.Sprintf("💾 DB Operations: %d (%s/min)\n", stats["db_operations"], stats["db_ops_per_minute"])
+	report += fmt.Sprintf("🌐 HTTP Requests: %d (%s/min)\n", stats["http_requests"], stats["http_per_minute"])
 	report += fmt.Sprintf("🎯 Cache Hit Rate: %s\n", stats["cache_hit_rate"])
 	report += fmt.Sprintf("🧠 Memory Usage: %s\n", stats["memory_alloc"])
 	report += fmt.Sprintf("🔄 Goroutines: %d\n", stats["goroutines"])
 	report += fmt.Sprintf("🗑️  GC Count: %d\n", stats["gc_count"])
+	report += fmt.Sprintf("📥 Queue: %d waiting, %d dropped (avg wait %s, max %s)\n", stats["queue_depth"], stats["queue_dropped"], stats["queue_wait_avg"], stats["queue_wait_max"])
 	
 	return report
 }
@@ -168,6 +211,10 @@ func (pm *PerformanceMonitor) Reset() {
 	pm.cacheMisses = 0
 	pm.dbOperations = 0
 	pm.httpRequests = 0
+	pm.queueDropped = 0
+	pm.queueWaited = 0
+	pm.queueWaitSum = 0
+	pm.queueWaitMax = 0
 }
 
 // formatBytes formats bytes into human readable format
@@ -0,0 +1,163 @@
+package libs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"zumygo/database"
+	"zumygo/helpers"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Bounds of the recent message store
+const (
+	recentPerChat  = 100
+	recentMaxChats = 300
+)
+
+// RecentMessage is a message kept so it can be reposted once deleted.
+// Media is kept as the original message, which references it by path and key.
+type RecentMessage struct {
+	ID        types.MessageID
+	Chat      types.JID
+	Sender    types.JID
+	Message   *waE2E.Message
+	Timestamp time.Time
+}
+
+// recentChat holds the latest messages of one chat, oldest first
+type recentChat struct {
+	messages []RecentMessage
+	lastSeen time.Time
+}
+
+var recentStore = struct {
+	sync.Mutex
+	chats map[types.JID]*recentChat
+}{chats: make(map[types.JID]*recentChat)}
+
+// RememberMessage keeps a message of a chat with anti-delete on, dropping the
+// oldest one of the chat, and the least active chat, once the bounds are hit.
+// View-once media is never kept, so a repost cannot make it permanent.
+func RememberMessage(m *IMessage) {
+	if m == nil || m.Message == nil || m.IsViewOnce || m.Info.IsFromMe || m.Info.Chat == types.StatusBroadcastJID {
+		return
+	}
+	if database.DB == nil || !database.DB.GetChatDelete(m.Info.Chat.String()) {
+		return
+	}
+
+	recentStore.Lock()
+	defer recentStore.Unlock()
+
+	chat, ok := recentStore.chats[m.Info.Chat]
+	if !ok {
+		if len(recentStore.chats) >= recentMaxChats {
+			evictRecentChat()
+		}
+		chat = &recentChat{}
+		recentStore.chats[m.Info.Chat] = chat
+	}
+
+	if len(chat.messages) >= recentPerChat {
+		chat.messages = append(chat.messages[:0], chat.messages[1:]...)
+	}
+	chat.messages = append(chat.messages, RecentMessage{
+		ID:        m.Info.ID,
+		Chat:      m.Info.Chat,
+		Sender:    m.Sender,
+		Message:   m.Message,
+		Timestamp: m.Info.Timestamp,
+	})
+	chat.lastSeen = time.Now()
+}
+
+// FindRecentMessage looks up a kept message by chat and ID
+func FindRecentMessage(chat types.JID, id types.MessageID) (RecentMessage, bool) {
+	recentStore.Lock()
+	defer recentStore.Unlock()
+
+	if recent, ok := recentStore.chats[chat]; ok {
+		for _, msg := range recent.messages {
+			if msg.ID == id {
+				return msg, true
+			}
+		}
+	}
+	return RecentMessage{}, false
+}
+
+// evictRecentChat forgets the chat that was active least recently
+func evictRecentChat() {
+	var oldest types.JID
+	var oldestSeen time.Time
+	for jid, chat := range recentStore.chats {
+		if oldestSeen.IsZero() || chat.lastSeen.Before(oldestSeen) {
+			oldest, oldestSeen = jid, chat.lastSeen
+		}
+	}
+	delete(recentStore.chats, oldest)
+}
+
+// RevokedID returns the ID of the message deleted for everyone by a raw
+// incoming message. Check it before serializing, which drops revokes.
+func RevokedID(message *waE2E.Message) (types.MessageID, bool) {
+	protocol := message.GetProtocolMessage()
+	if protocol == nil || protocol.GetType() != waE2E.ProtocolMessage_REVOKE {
+		return "", false
+	}
+	return protocol.GetKey().GetID(), true
+}
+
+// RepostDeleted reposts the message with the given ID revoked by m when
+// anti-delete is on in the chat and the message is still kept, reporting
+// whether it did
+func RepostDeleted(conn *IClient, m *IMessage, id types.MessageID) bool {
+	if conn == nil || conn.Messenger == nil || database.DB == nil {
+		return false
+	}
+	if !database.DB.GetChatDelete(m.Info.Chat.String()) {
+		return false
+	}
+
+	original, ok := FindRecentMessage(m.Info.Chat, id)
+	if !ok {
+		return false
+	}
+
+	notice := m.T("antidelete.notice", original.Sender.User)
+
+	// Plain text goes in the notice itself; anything else follows it as a copy
+	text := original.Message.GetConversation()
+	if text == "" {
+		text = original.Message.GetExtendedTextMessage().GetText()
+	}
+	if text != "" {
+		_, err := conn.Message(m.Info.Chat).Text(notice + "\n\n" + text).Mention(original.Sender).Send(context.Background())
+		return err == nil
+	}
+
+	if _, err := conn.Message(m.Info.Chat).Text(notice).Mention(original.Sender).Send(context.Background()); err != nil {
+		return false
+	}
+	if _, err := conn.Messenger.SendMessage(context.Background(), m.Info.Chat, repostCopy(original.Message)); err != nil {
+		helpers.Logger{}.Error(fmt.Sprintf("Failed to repost deleted message %s: %v", original.ID, err))
+		return false
+	}
+	return true
+}
+
+// repostCopy returns the message without its reply context and per-message
+// secrets, ready to be sent again
+func repostCopy(message *waE2E.Message) *waE2E.Message {
+	clone := proto.Clone(message).(*waE2E.Message)
+	clone.MessageContextInfo = nil
+	if contextInfo := helpers.GetContextInfo(clone); contextInfo != nil {
+		proto.Reset(contextInfo)
+	}
+	return clone
+}
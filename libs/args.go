@@ -0,0 +1,395 @@
+package libs
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"zumygo/locales"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ArgType is the kind of value a command parameter accepts
+type ArgType int
+
+const (
+	ArgString   ArgType = iota // A single word
+	ArgText                    // Everything that is left, joined by spaces
+	ArgInt                     // Whole number
+	ArgDuration                // Go duration such as 30s, 5m, 1h, with a d suffix for days
+	ArgURL                     // Absolute http(s) URL
+	ArgJID                     // @mention, phone number or JID; falls back to mentions and the quoted sender
+	ArgEnum                    // One of Choices
+	ArgFlag                    // Boolean switch written as --name
+	ArgOption                  // Named word written as --name value
+)
+
+// IArg declares one parameter of a command
+type IArg struct {
+	Name        string
+	Type        ArgType
+	Required    bool
+	Choices     []string
+	Description string
+}
+
+// IParams holds the typed values parsed from a message for a command
+type IParams struct {
+	values map[string]interface{}
+}
+
+// ArgError reports input that does not match a command declaration
+type ArgError struct {
+	Arg    *IArg
+	Key    string
+	Values []interface{}
+}
+
+func (e *ArgError) Error() string {
+	return locales.T(locales.EN, e.Key, e.Values...)
+}
+
+// Localize renders the error in the given language
+func (e *ArgError) Localize(lang string) string {
+	return locales.T(lang, e.Key, e.Values...)
+}
+
+var durationRegex = regexp.MustCompile(`^(\d+)d$`)
+
+// ParseArgs validates the message arguments against the declaration and
+// fills the typed parameters
+func ParseArgs(specs []IArg, conn *IClient, m *IMessage) (*IParams, error) {
+	params := &IParams{values: make(map[string]interface{})}
+
+	// Split flags and options from positional arguments
+	flags := make(map[string]*IArg)
+	for i := range specs {
+		if specs[i].Type == ArgFlag || specs[i].Type == ArgOption {
+			flags[strings.ToLower(specs[i].Name)] = &specs[i]
+		}
+	}
+
+	tokens := tokenize(m.Args)
+	var positional []argToken
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if !token.quoted && strings.HasPrefix(token.value, "--") {
+			if spec, ok := flags[strings.ToLower(strings.TrimPrefix(token.value, "--"))]; ok {
+				if spec.Type == ArgFlag {
+					params.values[spec.Name] = true
+					continue
+				}
+				if i+1 >= len(tokens) {
+					return nil, &ArgError{Arg: spec, Key: "args.missing", Values: []interface{}{spec.Name}}
+				}
+				i++
+				params.values[spec.Name] = tokens[i].value
+				continue
+			}
+		}
+		positional = append(positional, token)
+	}
+
+	for i := range specs {
+		spec := &specs[i]
+		if spec.Type == ArgFlag {
+			continue
+		}
+		if spec.Type == ArgOption {
+			if _, ok := params.values[spec.Name]; !ok && spec.Required {
+				return nil, &ArgError{Arg: spec, Key: "args.missing", Values: []interface{}{spec.Name}}
+			}
+			continue
+		}
+
+		if spec.Type == ArgText {
+			if len(positional) > 0 {
+				raw := make([]string, len(positional))
+				for j, token := range positional {
+					raw[j] = token.raw
+				}
+				params.values[spec.Name] = strings.Join(raw, " ")
+				positional = nil
+				continue
+			}
+		} else if len(positional) > 0 {
+			value, err := parseArg(spec, positional[0].value, conn)
+			if err != nil {
+				return nil, err
+			}
+			params.values[spec.Name] = value
+			positional = positional[1:]
+			continue
+		}
+
+		if spec.Type == ArgJID {
+			if jid, ok := implicitJID(m); ok {
+				params.values[spec.Name] = jid
+				continue
+			}
+		}
+
+		if spec.Required {
+			return nil, &ArgError{Arg: spec, Key: "args.missing", Values: []interface{}{spec.Name}}
+		}
+	}
+
+	return params, nil
+}
+
+// argToken is one argument: a word, or words grouped by double quotes
+type argToken struct {
+	value  string // Without the quotes
+	raw    string // As typed, for trailing text
+	quoted bool
+}
+
+// tokenize groups words between double quotes into single tokens; an
+// unterminated quote is taken literally
+func tokenize(args []string) []argToken {
+	var tokens []argToken
+	for i := 0; i < len(args); i++ {
+		word := args[i]
+		if !strings.HasPrefix(word, `"`) {
+			tokens = append(tokens, argToken{value: word, raw: word})
+			continue
+		}
+
+		end := -1
+		for j := i; j < len(args); j++ {
+			if strings.HasSuffix(args[j], `"`) && (j > i || len(word) > 1) {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			tokens = append(tokens, argToken{value: word, raw: word})
+			continue
+		}
+
+		raw := strings.Join(args[i:end+1], " ")
+		tokens = append(tokens, argToken{value: raw[1 : len(raw)-1], raw: raw, quoted: true})
+		i = end
+	}
+	return tokens
+}
+
+// parseArg converts a single token according to its declaration
+func parseArg(spec *IArg, token string, conn *IClient) (interface{}, error) {
+	switch spec.Type {
+	case ArgInt:
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, &ArgError{Arg: spec, Key: "args.number", Values: []interface{}{spec.Name}}
+		}
+		return value, nil
+
+	case ArgDuration:
+		value, ok := ParseDuration(token)
+		if !ok {
+			return nil, &ArgError{Arg: spec, Key: "args.duration", Values: []interface{}{spec.Name}}
+		}
+		return value, nil
+
+	case ArgURL:
+		value, err := url.Parse(token)
+		if err != nil || (value.Scheme != "http" && value.Scheme != "https") || value.Host == "" {
+			return nil, &ArgError{Arg: spec, Key: "args.url", Values: []interface{}{spec.Name}}
+		}
+		return value, nil
+
+	case ArgJID:
+		jid, ok := conn.ParseJID(strings.TrimPrefix(token, "@"))
+		if !ok {
+			return nil, &ArgError{Arg: spec, Key: "args.jid", Values: []interface{}{spec.Name}}
+		}
+		return jid, nil
+
+	case ArgEnum:
+		for _, choice := range spec.Choices {
+			if strings.EqualFold(choice, token) {
+				return choice, nil
+			}
+		}
+		return nil, &ArgError{Arg: spec, Key: "args.enum", Values: []interface{}{spec.Name, strings.Join(spec.Choices, ", ")}}
+	}
+
+	return token, nil
+}
+
+// ParseDuration parses a positive Go duration such as 30s, 5m or 1h, with a
+// d suffix for whole days
+func ParseDuration(token string) (time.Duration, bool) {
+	if match := durationRegex.FindStringSubmatch(token); match != nil {
+		days, _ := strconv.Atoi(match[1])
+		return time.Duration(days) * 24 * time.Hour, days > 0
+	}
+	value, err := time.ParseDuration(token)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// implicitJID returns the first mentioned user or the quoted message sender,
+// by phone number when known
+func implicitJID(m *IMessage) (types.JID, bool) {
+	if len(m.Mentions) > 0 {
+		return m.Client.ResolvePN(m.Mentions[0]), true
+	}
+	if !m.QuotedSender.IsEmpty() {
+		return m.Client.ResolvePN(m.QuotedSender), true
+	}
+	return types.JID{}, false
+}
+
+// Usage builds the usage reply for a command from its declaration, with
+// argument descriptions translated into lang
+func Usage(cmd *ICommand, prefix, lang string) string {
+	if cmd == nil {
+		return ""
+	}
+
+	name := cmd.Name
+	if len(cmd.As) > 0 {
+		name = cmd.As[0]
+	}
+
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s%s", locales.T(lang, "args.usage"), prefix, name))
+
+	for _, spec := range cmd.Args {
+		var token string
+		switch spec.Type {
+		case ArgFlag:
+			token = "--" + spec.Name
+		case ArgOption:
+			token = "--" + spec.Name + " " + spec.Name
+		case ArgEnum:
+			token = strings.Join(spec.Choices, "|")
+		case ArgText:
+			token = spec.Name + "..."
+		default:
+			token = spec.Name
+		}
+
+		if spec.Required {
+			str.WriteString(" <" + token + ">")
+		} else {
+			str.WriteString(" [" + token + "]")
+		}
+	}
+
+	for _, spec := range cmd.Args {
+		if spec.Description != "" {
+			str.WriteString(fmt.Sprintf("\n• %s - %s", spec.Name, locales.T(lang, spec.Description)))
+		}
+	}
+
+	return str.String()
+}
+
+// Has reports whether a parameter was given or filled implicitly
+func (p *IParams) Has(name string) bool {
+	if p == nil {
+		return false
+	}
+	_, ok := p.values[name]
+	return ok
+}
+
+// String returns a string, text or enum parameter
+func (p *IParams) String(name string) string {
+	if p == nil {
+		return ""
+	}
+	value, _ := p.values[name].(string)
+	return value
+}
+
+// Int returns an integer parameter
+func (p *IParams) Int(name string) int {
+	if p == nil {
+		return 0
+	}
+	value, _ := p.values[name].(int)
+	return value
+}
+
+// Duration returns a duration parameter
+func (p *IParams) Duration(name string) time.Duration {
+	if p == nil {
+		return 0
+	}
+	value, _ := p.values[name].(time.Duration)
+	return value
+}
+
+// URL returns a URL parameter
+func (p *IParams) URL(name string) *url.URL {
+	if p == nil {
+		return nil
+	}
+	value, _ := p.values[name].(*url.URL)
+	return value
+}
+
+// JID returns a mention or JID parameter
+func (p *IParams) JID(name string) types.JID {
+	if p == nil {
+		return types.JID{}
+	}
+	value, _ := p.values[name].(types.JID)
+	return value
+}
+
+// Flag returns whether a --flag was given
+func (p *IParams) Flag(name string) bool {
+	if p == nil {
+		return false
+	}
+	value, _ := p.values[name].(bool)
+	return value
+}
+
+// Bind copies the parameters into the exported fields of a struct pointer,
+// matching the `arg` tag or the lowercased field name
+func (p *IParams) Bind(dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a struct pointer")
+	}
+	if p == nil {
+		return nil
+	}
+
+	target = target.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("arg")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		value, ok := p.values[name]
+		if !ok {
+			continue
+		}
+
+		source := reflect.ValueOf(value)
+		if !source.Type().AssignableTo(field.Type) {
+			return fmt.Errorf("cannot bind %s (%s) to field %s (%s)", name, source.Type(), field.Name, field.Type)
+		}
+		target.Field(i).Set(source)
+	}
+
+	return nil
+}
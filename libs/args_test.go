@@ -0,0 +1,134 @@
+package libs
+
+import (
+	"strings"
+	"testing"
+	"zumygo/locales"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestParseArgs(t *testing.T) {
+	specs := []IArg{
+		{Name: "count", Type: ArgInt, Required: true},
+		{Name: "mode", Type: ArgEnum, Choices: []string{"fast", "slow"}},
+		{Name: "silent", Type: ArgFlag},
+		{Name: "title", Type: ArgOption},
+		{Name: "message", Type: ArgText},
+	}
+
+	cases := []struct {
+		name    string
+		input   string
+		wantErr string
+		check   func(p *IParams) bool
+	}{
+		{"missing required", "", "args.missing", nil},
+		{"bad number", "many", "args.number", nil},
+		{"bad enum", "3 medium", "args.enum", nil},
+		{"positional only", "3", "", func(p *IParams) bool {
+			return p.Int("count") == 3 && !p.Has("mode") && !p.Has("message")
+		}},
+		{"enum is case-insensitive", "3 FAST", "", func(p *IParams) bool {
+			return p.String("mode") == "fast"
+		}},
+		{"flag anywhere", "--silent 3 slow", "", func(p *IParams) bool {
+			return p.Flag("silent") && p.Int("count") == 3 && p.String("mode") == "slow"
+		}},
+		{"option with value", "3 --title hello slow", "", func(p *IParams) bool {
+			return p.String("title") == "hello" && p.String("mode") == "slow"
+		}},
+		{"option without value", "3 --title", "args.missing", nil},
+		{"quoted option value", `3 --title "two words" fast`, "", func(p *IParams) bool {
+			return p.String("title") == "two words" && p.String("mode") == "fast"
+		}},
+		{"quoted positional", `"3" fast`, "", func(p *IParams) bool {
+			return p.Int("count") == 3
+		}},
+		{"quoted flag is text", `3 fast "--silent"`, "", func(p *IParams) bool {
+			return !p.Flag("silent") && p.String("message") == `"--silent"`
+		}},
+		{"trailing text", `3 slow hello "big" world`, "", func(p *IParams) bool {
+			return p.String("message") == `hello "big" world`
+		}},
+		{"unterminated quote", `3 slow "hello world`, "", func(p *IParams) bool {
+			return p.String("message") == `"hello world`
+		}},
+	}
+
+	for _, c := range cases {
+		m := &IMessage{Args: strings.Fields(c.input)}
+		params, err := ParseArgs(specs, nil, m)
+		if c.wantErr != "" {
+			argErr, ok := err.(*ArgError)
+			if !ok || argErr.Key != c.wantErr {
+				t.Errorf("%s: err = %v, want %s", c.name, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		if !c.check(params) {
+			t.Errorf("%s: params = %v", c.name, params.values)
+		}
+	}
+}
+
+func TestParseArgsImplicitJID(t *testing.T) {
+	specs := []IArg{{Name: "user", Type: ArgJID, Required: true}}
+	quoted := types.NewJID("6281234567890", types.DefaultUserServer)
+
+	params, err := ParseArgs(specs, nil, &IMessage{QuotedSender: quoted})
+	if err != nil || params.JID("user") != quoted {
+		t.Errorf("user = %v (%v), want the quoted sender", params.JID("user"), err)
+	}
+	if _, err := ParseArgs(specs, nil, &IMessage{}); err == nil {
+		t.Errorf("missing user accepted")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	cmd := &ICommand{
+		Name: "(remind|rm)",
+		As:   []string{"remind"},
+		Args: []IArg{
+			{Name: "when", Type: ArgDuration, Required: true, Description: "args.usage"},
+			{Name: "mode", Type: ArgEnum, Choices: []string{"once", "daily"}},
+			{Name: "silent", Type: ArgFlag},
+			{Name: "tag", Type: ArgOption},
+			{Name: "text", Type: ArgText, Required: true},
+		},
+	}
+
+	want := locales.T(locales.EN, "args.usage") + " .remind <when> [once|daily] [--silent] [--tag tag] <text...>\n• when - " + locales.T(locales.EN, "args.usage")
+	if got := Usage(cmd, ".", locales.EN); got != want {
+		t.Errorf("Usage =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestBind(t *testing.T) {
+	params := &IParams{values: map[string]interface{}{"count": 3, "title": "hi", "silent": true}}
+
+	var dst struct {
+		Count  int
+		Name   string `arg:"title"`
+		Silent bool
+		Other  string
+	}
+	if err := params.Bind(&dst); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if dst.Count != 3 || dst.Name != "hi" || !dst.Silent || dst.Other != "" {
+		t.Errorf("bound %+v", dst)
+	}
+
+	var wrong struct{ Count string }
+	if err := params.Bind(&wrong); err == nil {
+		t.Errorf("Bind of int into string succeeded")
+	}
+	if err := params.Bind(dst); err == nil {
+		t.Errorf("Bind to a non-pointer succeeded")
+	}
+}
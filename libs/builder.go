@@ -0,0 +1,478 @@
+package libs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media kinds a builder can attach
+const (
+	MediaImage    = "image"
+	MediaVideo    = "video"
+	MediaDocument = "document"
+	MediaAudio    = "audio"
+	MediaVoice    = "voice"
+	MediaSticker  = "sticker"
+)
+
+var (
+	// chatExpirations holds the disappearing message timer last seen in
+	// each chat, so messages not quoting anything still follow it
+	chatExpirations     = make(map[types.JID]uint32)
+	chatExpirationMutex sync.RWMutex
+)
+
+// rememberExpiration records the disappearing message timer of a chat as
+// carried by an incoming message
+func rememberExpiration(chat types.JID, expiration uint32) {
+	if chat.IsEmpty() || chat == types.StatusBroadcastJID {
+		return
+	}
+
+	chatExpirationMutex.Lock()
+	defer chatExpirationMutex.Unlock()
+
+	if expiration == 0 {
+		delete(chatExpirations, chat)
+		return
+	}
+	chatExpirations[chat] = expiration
+}
+
+// chatExpiration returns the disappearing message timer of a chat, 0 when
+// off or unknown
+func chatExpiration(chat types.JID) uint32 {
+	chatExpirationMutex.RLock()
+	defer chatExpirationMutex.RUnlock()
+
+	return chatExpirations[chat]
+}
+
+// maxSentIDs bounds how many sent message IDs are remembered
+const maxSentIDs = 500
+
+// sentIDs holds the IDs of the latest messages the bot sent, oldest first,
+// so quotes of them can be attributed to the bot
+var sentIDs = struct {
+	sync.Mutex
+	order []types.MessageID
+	ids   map[types.MessageID]bool
+}{ids: make(map[types.MessageID]bool)}
+
+// rememberSent records the ID of a message the bot sent
+func rememberSent(id types.MessageID) {
+	if id == "" {
+		return
+	}
+
+	sentIDs.Lock()
+	defer sentIDs.Unlock()
+
+	if len(sentIDs.order) >= maxSentIDs {
+		delete(sentIDs.ids, sentIDs.order[0])
+		sentIDs.order = append(sentIDs.order[:0], sentIDs.order[1:]...)
+	}
+	sentIDs.order = append(sentIDs.order, id)
+	sentIDs.ids[id] = true
+}
+
+// sentByBot reports whether the message with the ID was sent by the bot
+func sentByBot(id types.MessageID) bool {
+	sentIDs.Lock()
+	defer sentIDs.Unlock()
+
+	return sentIDs.ids[id]
+}
+
+// IMessageBuilder composes an outgoing message: text or media with a
+// caption, plus the quoting, mentions, disappearing timer and forwarding
+// context the one-off Send methods leave to the caller.
+//
+//	conn.Message(m.Info.Chat).Text("done @628123").Mention(jid).Quote(m).Send(ctx)
+type IMessageBuilder struct {
+	conn *IClient
+	to   types.JID
+
+	text     string
+	kind     string
+	media    []byte
+	path     string
+	reader   io.Reader
+	fileName string
+	mimetype string
+
+	mentions   []types.JID
+	quoted     *IMessage
+	expiration *uint32
+	forwarded  uint32
+	newsletter *waE2E.ContextInfo_ForwardedNewsletterMessageInfo
+	extra      []whatsmeow.SendRequestExtra
+
+	// interactive builds buttons or a list around the text, when set
+	interactive func(text string, info *waE2E.ContextInfo) (*waE2E.Message, error)
+}
+
+// Message starts a message to a chat
+func (conn *IClient) Message(to types.JID) *IMessageBuilder {
+	return &IMessageBuilder{conn: conn, to: to}
+}
+
+// Text sets the message text, or the caption when media is attached
+func (b *IMessageBuilder) Text(text string) *IMessageBuilder {
+	b.text = text
+	return b
+}
+
+// Mention adds users to notify; the text should name them as @number
+func (b *IMessageBuilder) Mention(jids ...types.JID) *IMessageBuilder {
+	b.mentions = append(b.mentions, jids...)
+	return b
+}
+
+// Quote replies to a message, taking on the disappearing timer of its chat
+func (b *IMessageBuilder) Quote(m *IMessage) *IMessageBuilder {
+	b.quoted = m
+	return b
+}
+
+// Image attaches an image
+func (b *IMessageBuilder) Image(data []byte) *IMessageBuilder {
+	return b.attach(MediaImage, data)
+}
+
+// Video attaches a video
+func (b *IMessageBuilder) Video(data []byte) *IMessageBuilder {
+	return b.attach(MediaVideo, data)
+}
+
+// Document attaches a file under the given name
+func (b *IMessageBuilder) Document(data []byte, fileName string) *IMessageBuilder {
+	b.fileName = fileName
+	return b.attach(MediaDocument, data)
+}
+
+// Audio attaches an audio file, played inline
+func (b *IMessageBuilder) Audio(data []byte) *IMessageBuilder {
+	return b.attach(MediaAudio, data)
+}
+
+// Voice attaches an Opus audio as a voice note
+func (b *IMessageBuilder) Voice(data []byte) *IMessageBuilder {
+	return b.attach(MediaVoice, data)
+}
+
+// Sticker attaches a WebP sticker
+func (b *IMessageBuilder) Sticker(data []byte) *IMessageBuilder {
+	return b.attach(MediaSticker, data)
+}
+
+// Mimetype overrides the type detected from the attached media
+func (b *IMessageBuilder) Mimetype(mimetype string) *IMessageBuilder {
+	b.mimetype = mimetype
+	return b
+}
+
+// Expiration sets the disappearing timer in seconds, overriding the one of
+// the chat; 0 sends a message that does not disappear
+func (b *IMessageBuilder) Expiration(seconds uint32) *IMessageBuilder {
+	b.expiration = &seconds
+	return b
+}
+
+// Forwarded marks the message as forwarded; a score of 5 or more shows as
+// forwarded many times
+func (b *IMessageBuilder) Forwarded(score uint32) *IMessageBuilder {
+	if score == 0 {
+		score = 1
+	}
+	b.forwarded = score
+	return b
+}
+
+// Newsletter shows the message as forwarded from a channel
+func (b *IMessageBuilder) Newsletter(jid, name string, serverID int32) *IMessageBuilder {
+	b.newsletter = &waE2E.ContextInfo_ForwardedNewsletterMessageInfo{
+		NewsletterJID:   proto.String(jid),
+		NewsletterName:  proto.String(name),
+		ServerMessageID: proto.Int32(serverID),
+		ContentType:     waE2E.ContextInfo_ForwardedNewsletterMessageInfo_UPDATE.Enum(),
+	}
+	return b
+}
+
+// Extra passes send options, such as a custom message ID, to the messenger
+func (b *IMessageBuilder) Extra(extra ...whatsmeow.SendRequestExtra) *IMessageBuilder {
+	b.extra = append(b.extra, extra...)
+	return b
+}
+
+// File attaches media of the given kind streamed from a file, such as one
+// from FetchFile, instead of held in memory
+func (b *IMessageBuilder) File(kind, path string) *IMessageBuilder {
+	b.kind, b.media, b.path, b.reader = kind, nil, path, nil
+	return b
+}
+
+// Reader attaches media of the given kind read from r when sending. It is
+// spooled to a temporary file first, to learn its size.
+func (b *IMessageBuilder) Reader(kind string, r io.Reader) *IMessageBuilder {
+	b.kind, b.media, b.path, b.reader = kind, nil, "", r
+	return b
+}
+
+// FileName names a document, or media sent as one for being too large
+func (b *IMessageBuilder) FileName(name string) *IMessageBuilder {
+	b.fileName = name
+	return b
+}
+
+// attach sets in-memory media, replacing any attached before
+func (b *IMessageBuilder) attach(kind string, data []byte) *IMessageBuilder {
+	b.kind, b.media, b.path, b.reader = kind, data, "", nil
+	return b
+}
+
+// Send uploads any media and sends the message
+func (b *IMessageBuilder) Send(ctx context.Context) (whatsmeow.SendResponse, error) {
+	if b.conn == nil || b.conn.Messenger == nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("client is not initialized")
+	}
+
+	message, err := b.build(ctx)
+	if err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	response, err := b.conn.Messenger.SendMessage(ctx, b.to, message, b.extra...)
+	if err == nil {
+		rememberSent(response.ID)
+	}
+	return response, err
+}
+
+// build assembles the message, uploading the media first
+func (b *IMessageBuilder) build(ctx context.Context) (*waE2E.Message, error) {
+	info := b.contextInfo()
+
+	if b.interactive != nil {
+		if b.kind != "" {
+			return nil, fmt.Errorf("interactive messages cannot carry media")
+		}
+		return b.interactive(b.text, info)
+	}
+
+	if b.kind == "" {
+		if b.text == "" {
+			return nil, fmt.Errorf("message is empty")
+		}
+		return &waE2E.Message{
+			ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+				Text:        proto.String(b.text),
+				ContextInfo: info,
+			},
+		}, nil
+	}
+
+	if b.text != "" && (b.kind == MediaAudio || b.kind == MediaVoice || b.kind == MediaSticker) {
+		return nil, fmt.Errorf("%s messages cannot have a caption", b.kind)
+	}
+
+	// Readers go to disk first, so their size is known before uploading
+	path := b.path
+	if b.reader != nil {
+		spooled, err := spoolTemp(b.reader, MediaLimits[MediaDocument])
+		if errors.Is(err, ErrMediaTooLarge) {
+			return nil, ErrUploadTooLarge
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", b.kind, err)
+		}
+		defer spooled.Cleanup()
+		path = spooled.Path
+	}
+
+	size := int64(len(b.media))
+	if path != "" {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", b.kind, err)
+		}
+		size = stat.Size()
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("%s data is empty", b.kind)
+	}
+
+	// Media over the inline limit of its kind goes out as a document
+	kind, err := fitMedia(b.kind, size)
+	if err != nil {
+		return nil, err
+	}
+
+	mimetype := b.mimetype
+	if mimetype == "" {
+		switch {
+		case kind == MediaAudio:
+			mimetype = "audio/mpeg"
+		case kind == MediaVoice:
+			mimetype = "audio/ogg; codecs=opus"
+		case kind == MediaSticker:
+			mimetype = "image/webp"
+		case path != "":
+			if mimetype, err = sniffFile(path); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %v", b.kind, err)
+			}
+		default:
+			mimetype = http.DetectContentType(b.media)
+		}
+	}
+
+	mediaType := map[string]whatsmeow.MediaType{
+		MediaImage:    whatsmeow.MediaImage,
+		MediaVideo:    whatsmeow.MediaVideo,
+		MediaDocument: whatsmeow.MediaDocument,
+		MediaAudio:    whatsmeow.MediaAudio,
+		MediaVoice:    whatsmeow.MediaAudio,
+		MediaSticker:  whatsmeow.MediaImage,
+	}[kind]
+
+	var uploaded whatsmeow.UploadResponse
+	if path == "" {
+		uploaded, err = b.conn.Messenger.Upload(ctx, b.media, mediaType)
+	} else {
+		file, openErr := os.Open(path)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", b.kind, openErr)
+		}
+		defer file.Close()
+		uploaded, err = b.conn.Messenger.UploadReader(ctx, file, mediaType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %v", kind, err)
+	}
+
+	// Temporary files have generated names, so only their extension is kept
+	fileName := b.fileName
+	if ext := filepath.Ext(b.path); fileName == "" && ext != "" && !isTempMedia(b.path) {
+		fileName = filepath.Base(b.path)
+	} else if fileName == "" {
+		if ext == "" {
+			ext = MediaExtension(mimetype, "")
+		}
+		fileName = b.kind + ext
+	}
+	length := proto.Uint64(uint64(size))
+
+	switch kind {
+	case MediaImage:
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Caption:       proto.String(b.text),
+			Mimetype:      proto.String(mimetype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    length,
+			ContextInfo:   info,
+		}}, nil
+	case MediaVideo:
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Caption:       proto.String(b.text),
+			Mimetype:      proto.String(mimetype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    length,
+			ContextInfo:   info,
+		}}, nil
+	case MediaDocument:
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			FileName:      proto.String(fileName),
+			Caption:       proto.String(b.text),
+			Mimetype:      proto.String(mimetype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    length,
+			ContextInfo:   info,
+		}}, nil
+	case MediaAudio, MediaVoice:
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(mimetype),
+			PTT:           proto.Bool(kind == MediaVoice),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    length,
+			ContextInfo:   info,
+		}}, nil
+	default:
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(mimetype),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    length,
+			ContextInfo:   info,
+		}}, nil
+	}
+}
+
+// contextInfo collects quoting, mentions, the disappearing timer and
+// forwarding into one context, nil when there is none
+func (b *IMessageBuilder) contextInfo() *waE2E.ContextInfo {
+	info := &waE2E.ContextInfo{}
+
+	expiration := chatExpiration(b.to)
+	if b.quoted != nil {
+		info.StanzaID = proto.String(b.quoted.Info.ID)
+		info.Participant = proto.String(b.quoted.Info.Sender.String())
+		info.QuotedMessage = b.quoted.Message
+		if b.quoted.Info.Chat != b.to {
+			info.RemoteJID = proto.String(b.quoted.Info.Chat.String())
+		} else {
+			expiration = b.quoted.Expiration
+		}
+	}
+	if b.expiration != nil {
+		expiration = *b.expiration
+	}
+	if expiration > 0 {
+		info.Expiration = proto.Uint32(expiration)
+	}
+
+	for _, jid := range b.mentions {
+		info.MentionedJID = append(info.MentionedJID, jid.ToNonAD().String())
+	}
+
+	if b.forwarded > 0 || b.newsletter != nil {
+		info.IsForwarded = proto.Bool(true)
+	}
+	if b.forwarded > 0 {
+		info.ForwardingScore = proto.Uint32(b.forwarded)
+	}
+	info.ForwardedNewsletterMessageInfo = b.newsletter
+
+	if proto.Size(info) == 0 {
+		return nil
+	}
+	return info
+}
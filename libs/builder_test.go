@@ -0,0 +1,113 @@
+package libs_test
+
+import (
+	"context"
+	"testing"
+	"zumygo/libs"
+	"zumygo/testkit"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBuilderAppliesContext(t *testing.T) {
+	h := testkit.New(t)
+	group := types.NewJID("120363000000000003", types.GroupServer)
+	target := types.NewJID("6283333333333", types.DefaultUserServer)
+
+	// A message in a disappearing chat sets the timer for later sends
+	m := h.Event(testkit.NewMessage(group, h.User, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String("hello"),
+			ContextInfo: &waE2E.ContextInfo{Expiration: proto.Uint32(86400)},
+		},
+	}))
+	h.Fake.Reset()
+
+	_, err := h.Client.Message(group).
+		Text("look @6283333333333").
+		Image([]byte("\x89PNG\r\n\x1a\nimage")).
+		Mention(target).
+		Quote(m).
+		Forwarded(5).
+		Send(context.Background())
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	sent := h.Fake.Sent()
+	if len(sent) != 1 || sent[0].Message.GetImageMessage() == nil {
+		t.Fatalf("sent = %v, want one image", sent)
+	}
+	image := sent[0].Message.GetImageMessage()
+	info := image.GetContextInfo()
+	if image.GetCaption() != "look @6283333333333" || image.GetMimetype() != "image/png" {
+		t.Errorf("caption = %q mimetype = %q", image.GetCaption(), image.GetMimetype())
+	}
+	if info.GetStanzaID() != m.Info.ID || info.GetParticipant() != h.User.String() {
+		t.Errorf("quote = %q from %q, want %q", info.GetStanzaID(), info.GetParticipant(), m.Info.ID)
+	}
+	if len(info.GetMentionedJID()) != 1 || info.GetMentionedJID()[0] != target.String() {
+		t.Errorf("mentions = %v, want [%v]", info.GetMentionedJID(), target)
+	}
+	if info.GetExpiration() != 86400 {
+		t.Errorf("expiration = %d, want the chat timer", info.GetExpiration())
+	}
+	if !info.GetIsForwarded() || info.GetForwardingScore() != 5 {
+		t.Errorf("forwarded = %v score = %d", info.GetIsForwarded(), info.GetForwardingScore())
+	}
+
+	// Sends that quote nothing still follow the chat timer
+	h.Client.Message(group).Text("later").Send(context.Background())
+	if got := h.Fake.Sent()[1].Message.GetExtendedTextMessage().GetContextInfo().GetExpiration(); got != 86400 {
+		t.Errorf("unquoted expiration = %d, want 86400", got)
+	}
+}
+
+func TestBuilderNewsletterAndPlainText(t *testing.T) {
+	h := testkit.New(t)
+
+	h.Client.Message(h.User).Text("news").Newsletter("120363000000000000@newsletter", "Channel", 7).Send(context.Background())
+	h.Client.Message(h.User).Text("plain").Send(context.Background())
+
+	sent := h.Fake.Sent()
+	news := sent[0].Message.GetExtendedTextMessage().GetContextInfo()
+	if !news.GetIsForwarded() || news.GetForwardedNewsletterMessageInfo().GetNewsletterName() != "Channel" {
+		t.Errorf("newsletter context = %v", news)
+	}
+	if info := sent[1].Message.GetExtendedTextMessage().GetContextInfo(); info != nil {
+		t.Errorf("plain text context = %v, want none", info)
+	}
+
+	if _, err := h.Client.Message(h.User).Text("caption").Audio([]byte("ID3")).Send(context.Background()); err == nil {
+		t.Errorf("audio with a caption sent, want an error")
+	}
+	if _, err := h.Client.Message(h.User).Send(context.Background()); err == nil {
+		t.Errorf("empty message sent, want an error")
+	}
+}
+
+func TestBuilderInteractive(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	h.Client.Message(h.User).Text("pick one").Buttons("footer", libs.IButton{ID: "menu", Text: "Menu"}).Quote(m).Send(context.Background())
+	h.Client.Message(h.User).Text("choose").List("", "Open", libs.IListSection{Rows: []libs.IListRow{{ID: "ping", Title: "Ping"}}}).Send(context.Background())
+
+	sent := h.Fake.Sent()
+	buttons := sent[0].Message.GetButtonsMessage()
+	if buttons.GetContentText() != "pick one" || buttons.GetButtons()[0].GetButtonID() != "menu" {
+		t.Errorf("buttons = %v", buttons)
+	}
+	if buttons.GetContextInfo().GetStanzaID() != m.Info.ID {
+		t.Errorf("buttons do not quote %s", m.Info.ID)
+	}
+	if list := sent[1].Message.GetListMessage(); list.GetSections()[0].GetRows()[0].GetRowID() != "ping" {
+		t.Errorf("list = %v", list)
+	}
+
+	if _, err := h.Client.Message(h.User).Image([]byte("\x89PNG")).Buttons("", libs.IButton{ID: "x"}).Send(context.Background()); err == nil {
+		t.Errorf("buttons with media sent, want an error")
+	}
+}
@@ -1,9 +1,9 @@
 package libs
 
 import (
-	"regexp"
 	"strings"
 	"zumygo/config"
+	"zumygo/database"
 )
 
 var lists []ICommand
@@ -25,6 +25,10 @@ func NewCommands(cmd *ICommand) {
 	}
 	
 	lists = append(lists, *cmd)
+	
+	// Index the command once so lookups never scan the list
+	entry := lists[len(lists)-1]
+	router.add(&entry)
 }
 
 func GetList() []ICommand {
@@ -41,12 +45,42 @@ func GetPrefixes() []string {
 
 
 
-// ExtractPrefix extracts the prefix from a command string
-func ExtractPrefix(command string) (string, bool) {
-	prefixes := GetPrefixes()
+// GetChatPrefixes returns the prefixes valid in a chat: its own overrides when
+// set, the global prefixes otherwise
+func GetChatPrefixes(chatID string) []string {
+	if chatID != "" && database.DB != nil {
+		if prefixes, _ := database.DB.GetChatPrefixes(chatID); len(prefixes) > 0 {
+			return prefixes
+		}
+	}
+	return GetPrefixes()
+}
+
+// DefaultPrefix returns the first prefix valid in a chat, used when a
+// command runs without one being typed
+func DefaultPrefix(chatID string) string {
+	if prefixes := GetChatPrefixes(chatID); len(prefixes) > 0 {
+		return prefixes[0]
+	}
+	return "."
+}
+
+// IsNoPrefixChat reports whether a chat accepts commands without a prefix
+func IsNoPrefixChat(chatID string) bool {
+	if chatID == "" || database.DB == nil {
+		return false
+	}
+	_, noPrefix := database.DB.GetChatPrefixes(chatID)
+	return noPrefix
+}
+
+// ExtractPrefix extracts the prefix from a command string using the prefixes
+// of the given chat; an empty chatID uses the global prefixes
+func ExtractPrefix(chatID, command string) (string, bool) {
+	prefixes := GetChatPrefixes(chatID)
 	
 	for _, prefix := range prefixes {
-		if strings.HasPrefix(command, prefix) {
+		if prefix != "" && strings.HasPrefix(command, prefix) {
 			return prefix, true
 		}
 	}
@@ -60,36 +94,13 @@ func HasCommand(name string) bool {
 	}
 	
 	// Check if name already has a prefix
-	prefix, hasPrefix := ExtractPrefix(name)
-	var commandName string
-	
+	prefix, hasPrefix := ExtractPrefix("", name)
+	commandName := name
 	if hasPrefix {
 		// Remove prefix to get the actual command
 		commandName = strings.TrimSpace(strings.TrimPrefix(name, prefix))
-	} else {
-		// No prefix, use name as is
-		commandName = name
 	}
 	
-	for _, cmd := range lists {
-		if cmd.Name == "" {
-			continue
-		}
-		
-		// Check if cmd.Name contains regex patterns (like |, *, +, etc.)
-		if strings.ContainsAny(cmd.Name, "|*+?()[]{}") {
-			// Use as regex pattern
-			re := regexp.MustCompile(`^` + cmd.Name + `$`)
-			if valid := len(re.FindAllString(commandName, -1)) > 0; valid {
-				return true
-			}
-		} else {
-			// Use exact match with quote meta for safety
-			re := regexp.MustCompile(`^` + regexp.QuoteMeta(cmd.Name) + `$`)
-			if valid := len(re.FindAllString(commandName, -1)) > 0; valid {
-				return true
-			}
-		}
-	}
-	return false
+	_, ok := Resolve(commandName)
+	return ok
 }
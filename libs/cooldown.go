@@ -0,0 +1,214 @@
+package libs
+
+import (
+	"sync"
+	"time"
+	"zumygo/config"
+	"zumygo/database"
+)
+
+// ICooldown declares how often a command may be used. Every scope with a
+// non-zero window allows Burst uses inside that window before blocking.
+type ICooldown struct {
+	User   time.Duration
+	Chat   time.Duration
+	Global time.Duration
+	Burst  int
+}
+
+// cooldownSweepInterval is how often keys with no usage left in their
+// window are dropped, from memory and from the database
+const cooldownSweepInterval = 10 * time.Minute
+
+// cooldownLimiter keeps sliding-window usage timestamps per scope key
+type cooldownLimiter struct {
+	mutex     sync.Mutex
+	usages    map[string][]int64
+	windows   map[string]time.Duration
+	lastSweep time.Time
+}
+
+var cooldowns = newCooldownLimiter()
+
+func newCooldownLimiter() *cooldownLimiter {
+	return &cooldownLimiter{
+		usages:    make(map[string][]int64),
+		windows:   make(map[string]time.Duration),
+		lastSweep: time.Now(),
+	}
+}
+
+// IsZero reports whether the cooldown declares no limits
+func (c ICooldown) IsZero() bool {
+	return c.User <= 0 && c.Chat <= 0 && c.Global <= 0
+}
+
+// CheckCooldown records a command use and returns how long the caller must
+// wait when a limit is hit; nothing is recorded in that case
+func CheckCooldown(cmd *ICommand, m *IMessage) (time.Duration, bool) {
+	return cooldowns.check(cmd, m, time.Now())
+}
+
+// check is CheckCooldown at a given time
+func (l *cooldownLimiter) check(cmd *ICommand, m *IMessage, now time.Time) (time.Duration, bool) {
+	if cmd == nil || m == nil || cmd.Cooldown.IsZero() {
+		return 0, true
+	}
+	if IsCooldownExempt(m) {
+		return 0, true
+	}
+
+	scopes := []struct {
+		key    string
+		window time.Duration
+	}{
+		{"user:" + cmd.Name + ":" + m.Sender.ToNonAD().String(), cmd.Cooldown.User},
+		{"chat:" + cmd.Name + ":" + m.Info.Chat.String(), cmd.Cooldown.Chat},
+		{"global:" + cmd.Name, cmd.Cooldown.Global},
+	}
+
+	burst := cmd.Cooldown.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if now.Sub(l.lastSweep) >= cooldownSweepInterval {
+		l.sweep(now)
+	}
+
+	var wait time.Duration
+	for _, scope := range scopes {
+		if scope.window <= 0 {
+			continue
+		}
+
+		stamps := l.prune(scope.key, scope.window, now)
+		if len(stamps) >= burst {
+			oldest := time.Unix(0, stamps[len(stamps)-burst])
+			if remaining := oldest.Add(scope.window).Sub(now); remaining > wait {
+				wait = remaining
+			}
+		}
+	}
+
+	if wait > 0 {
+		return wait, false
+	}
+
+	for _, scope := range scopes {
+		if scope.window <= 0 {
+			continue
+		}
+		l.record(scope.key, scope.window, now)
+	}
+
+	return 0, true
+}
+
+// IsCooldownExempt reports whether the sender skips cooldowns by configuration
+func IsCooldownExempt(m *IMessage) bool {
+	cfg := config.Config
+	if cfg == nil {
+		return false
+	}
+
+	if cfg.CooldownExemptOwner && m.IsOwner {
+		return true
+	}
+
+	if cfg.CooldownExemptPremium {
+		if cfg.IsPrem(m.Sender.ToNonAD().String()) || (m.User != nil && m.User.Premium) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prune drops timestamps outside the window, loading persisted state once
+func (l *cooldownLimiter) prune(key string, window time.Duration, now time.Time) []int64 {
+	stamps, exists := l.usages[key]
+	if !exists && persistCooldowns() {
+		stamps = database.DB.GetCooldown(key)
+	}
+
+	cutoff := now.Add(-window).UnixNano()
+	kept := stamps[:0]
+	for _, stamp := range stamps {
+		if stamp > cutoff {
+			kept = append(kept, stamp)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(l.usages, key)
+		delete(l.windows, key)
+		if exists && persistCooldowns() {
+			database.DB.SetCooldown(key, nil)
+		}
+		return nil
+	}
+
+	l.usages[key] = kept
+	l.windows[key] = window
+	return kept
+}
+
+// record appends a use to a scope key
+func (l *cooldownLimiter) record(key string, window time.Duration, now time.Time) {
+	l.usages[key] = append(l.usages[key], now.UnixNano())
+	l.windows[key] = window
+
+	if persistCooldowns() {
+		database.DB.SetCooldown(key, l.usages[key])
+	}
+}
+
+// sweep drops every key whose newest use has left its window. Persisted keys
+// not loaded since startup are dropped once past the longest window in use,
+// as no command can still be limited by them.
+func (l *cooldownLimiter) sweep(now time.Time) {
+	l.lastSweep = now
+
+	for key, stamps := range l.usages {
+		if len(stamps) > 0 && stamps[len(stamps)-1] > now.Add(-l.windows[key]).UnixNano() {
+			continue
+		}
+		delete(l.usages, key)
+		delete(l.windows, key)
+		if persistCooldowns() {
+			database.DB.SetCooldown(key, nil)
+		}
+	}
+
+	if persistCooldowns() {
+		longest := longestCooldown()
+		for _, window := range l.windows {
+			if window > longest {
+				longest = window
+			}
+		}
+		database.DB.PruneCooldowns(now.Add(-longest).UnixNano())
+	}
+}
+
+// longestCooldown returns the longest window declared by any command
+func longestCooldown() time.Duration {
+	var longest time.Duration
+	for _, cmd := range lists {
+		for _, window := range []time.Duration{cmd.Cooldown.User, cmd.Cooldown.Chat, cmd.Cooldown.Global} {
+			if window > longest {
+				longest = window
+			}
+		}
+	}
+	return longest
+}
+
+// persistCooldowns reports whether cooldown state is mirrored into the database
+func persistCooldowns() bool {
+	return config.Config != nil && config.Config.PersistCooldowns && database.DB != nil
+}
@@ -0,0 +1,130 @@
+package libs
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	"zumygo/config"
+	"zumygo/database"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// useCooldownConfig installs a config and database for one test
+func useCooldownConfig(t *testing.T, cfg *config.BotConfig, persist bool) {
+	prevConfig, prevDB := config.Config, database.DB
+	t.Cleanup(func() { config.Config, database.DB = prevConfig, prevDB })
+
+	config.Config = cfg
+	database.DB = nil
+	if persist {
+		cfg.PersistCooldowns = true
+		if _, err := database.InitDatabase(filepath.Join(t.TempDir(), "database.json")); err != nil {
+			t.Fatalf("init database: %v", err)
+		}
+	}
+}
+
+func TestCheckCooldown(t *testing.T) {
+	user := types.NewJID("6281111111111", types.DefaultUserServer)
+	other := types.NewJID("6282222222222", types.DefaultUserServer)
+	chat := types.NewJID("120363000000000001", types.GroupServer)
+
+	message := func(sender types.JID, owner bool) *IMessage {
+		return &IMessage{Sender: sender, IsOwner: owner, Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: chat, Sender: sender}}}
+	}
+
+	type use struct {
+		at     time.Duration // Since the start of the case
+		m      *IMessage
+		wantOK bool
+	}
+	cases := []struct {
+		name     string
+		cooldown ICooldown
+		uses     []use
+	}{
+		{"single use per window", ICooldown{User: 10 * time.Second}, []use{
+			{0, message(user, false), true},
+			{time.Second, message(user, false), false},
+			{time.Second, message(other, false), true},
+		}},
+		{"burst", ICooldown{User: 10 * time.Second, Burst: 3}, []use{
+			{0, message(user, false), true},
+			{time.Second, message(user, false), true},
+			{2 * time.Second, message(user, false), true},
+			{3 * time.Second, message(user, false), false},
+		}},
+		{"window expiry", ICooldown{User: 10 * time.Second, Burst: 2}, []use{
+			{0, message(user, false), true},
+			{5 * time.Second, message(user, false), true},
+			{9 * time.Second, message(user, false), false},
+			{11 * time.Second, message(user, false), true},
+			{12 * time.Second, message(user, false), false},
+			{16 * time.Second, message(user, false), true},
+		}},
+		{"chat scope covers every sender", ICooldown{Chat: 10 * time.Second}, []use{
+			{0, message(user, false), true},
+			{time.Second, message(other, false), false},
+		}},
+		{"owner exempt", ICooldown{Global: time.Minute}, []use{
+			{0, message(user, true), true},
+			{time.Second, message(user, true), true},
+			{2 * time.Second, message(other, false), true},
+			{3 * time.Second, message(user, false), false},
+		}},
+	}
+
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	for _, c := range cases {
+		useCooldownConfig(t, &config.BotConfig{CooldownExemptOwner: true}, false)
+		limiter := newCooldownLimiter()
+		limiter.lastSweep = start
+		cmd := &ICommand{Name: c.name, Cooldown: c.cooldown}
+
+		for i, u := range c.uses {
+			wait, ok := limiter.check(cmd, u.m, start.Add(u.at))
+			if ok != u.wantOK {
+				t.Errorf("%s: use %d ok = %v (wait %v), want %v", c.name, i, ok, wait, u.wantOK)
+			}
+			if !ok && wait <= 0 {
+				t.Errorf("%s: use %d blocked without a wait", c.name, i)
+			}
+		}
+	}
+}
+
+func TestCooldownSweep(t *testing.T) {
+	useCooldownConfig(t, &config.BotConfig{}, true)
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	sender := types.NewJID("6281111111111", types.DefaultUserServer)
+	m := &IMessage{Sender: sender, Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: sender}}}
+
+	limiter := newCooldownLimiter()
+	limiter.lastSweep = start
+	short := &ICommand{Name: "short", Cooldown: ICooldown{User: time.Minute}}
+	long := &ICommand{Name: "long", Cooldown: ICooldown{User: time.Hour}}
+	limiter.check(short, m, start)
+	limiter.check(long, m, start)
+
+	// A key left over from an earlier run, for a command long gone
+	database.DB.SetCooldown("user:removed:"+sender.String(), []int64{start.Add(-48 * time.Hour).UnixNano()})
+
+	limiter.check(&ICommand{Name: "other", Cooldown: ICooldown{User: time.Second}}, m, start.Add(cooldownSweepInterval))
+
+	if _, ok := limiter.usages["user:short:"+sender.String()]; ok {
+		t.Errorf("expired key kept in memory")
+	}
+	if _, ok := limiter.usages["user:long:"+sender.String()]; !ok {
+		t.Errorf("key inside its window swept")
+	}
+	if stamps := database.DB.GetCooldown("user:short:" + sender.String()); stamps != nil {
+		t.Errorf("expired key kept in the database: %v", stamps)
+	}
+	if stamps := database.DB.GetCooldown("user:removed:" + sender.String()); stamps != nil {
+		t.Errorf("stale persisted key kept: %v", stamps)
+	}
+	if stamps := database.DB.GetCooldown("user:long:" + sender.String()); stamps == nil {
+		t.Errorf("live persisted key removed")
+	}
+}
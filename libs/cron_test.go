@@ -0,0 +1,81 @@
+package libs
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// Friday 2026-10-16 10:30
+	base := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 16, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC)},
+		{"0 8 * * *", time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * mon-fri", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)},
+		{"30 10 * * 5", time.Date(2026, 10, 23, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 feb *", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"0 9 1,15 * 0", time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+		{"5-10/5 11 * * *", time.Date(2026, 10, 16, 11, 5, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		schedule, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", c.expr, err)
+		}
+		if got := schedule.Next(base); !got.Equal(c.want) {
+			t.Errorf("Next(%q) = %v, want %v", c.expr, got, c.want)
+		}
+	}
+}
+
+func TestCronDaySteps(t *testing.T) {
+	// Odd days of October 2026, whatever way the weekday spells "every day"
+	var want []int
+	for day := 1; day <= 31; day += 2 {
+		want = append(want, day)
+	}
+
+	for _, expr := range []string{"0 9 */2 * *", "0 9 */2 * */1", "0 9 */2 * 0-6", "0 9 1-31/2 * sun-sat"} {
+		schedule, err := ParseCron(expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", expr, err)
+		}
+
+		var got []int
+		for next := schedule.Next(time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)); next.Month() == time.October; next = schedule.Next(next) {
+			got = append(got, next.Day())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%q fired on %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestCronNeverMatches(t *testing.T) {
+	schedule, err := ParseCron("0 0 30 feb *")
+	if err != nil {
+		t.Fatalf("ParseCron: %v", err)
+	}
+	if got := schedule.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next = %v, want zero time", got)
+	}
+}
+
+func TestCronInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "@often"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
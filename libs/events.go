@@ -0,0 +1,120 @@
+package libs
+
+import (
+	"fmt"
+	"sync"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// EventType identifies a kind of event published on the event bus
+type EventType string
+
+const (
+	EventMessage           EventType = "message"            // Any incoming message
+	EventStatus            EventType = "status"             // Status update posted to status@broadcast
+	EventGroupParticipants EventType = "group_participants" // Users joined, left, were promoted or demoted
+	EventReceipt           EventType = "receipt"            // Delivery or read receipt
+	EventCall              EventType = "call"               // Incoming call offer
+	EventConnected         EventType = "connected"          // Socket connected
+)
+
+// Group participant actions carried by EventGroupParticipants
+const (
+	ActionJoin    = "join"
+	ActionLeave   = "leave"
+	ActionPromote = "promote"
+	ActionDemote  = "demote"
+)
+
+// IEvent is a single event delivered to listeners
+type IEvent struct {
+	Type         EventType
+	Message      *IMessage // Set for message and status events
+	Chat         types.JID
+	Sender       types.JID
+	Action       string      // Set for group participant events
+	Participants []types.JID // Set for group participant events
+	Raw          interface{} // The original whatsmeow event
+}
+
+// EventListener handles an event published on the bus
+type EventListener func(conn *IClient, evt *IEvent)
+
+var (
+	listeners      = make(map[EventType][]EventListener)
+	listenersMutex sync.RWMutex
+
+	// inFlight counts listener and other background goroutines
+	inFlight sync.WaitGroup
+)
+
+// On subscribes a listener to an event type
+func On(eventType EventType, listener EventListener) {
+	if listener == nil {
+		return
+	}
+
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+
+	listeners[eventType] = append(listeners[eventType], listener)
+}
+
+// HasListeners reports whether any listener is subscribed to an event type
+func HasListeners(eventType EventType) bool {
+	listenersMutex.RLock()
+	defer listenersMutex.RUnlock()
+
+	return len(listeners[eventType]) > 0
+}
+
+// Emit delivers an event to its listeners asynchronously so that slow
+// listeners never block the WhatsApp event loop
+func Emit(conn *IClient, evt *IEvent) {
+	if evt == nil {
+		return
+	}
+
+	listenersMutex.RLock()
+	subscribed := make([]EventListener, len(listeners[evt.Type]))
+	copy(subscribed, listeners[evt.Type])
+	listenersMutex.RUnlock()
+
+	if len(subscribed) == 0 {
+		return
+	}
+
+	Background(func() {
+		for _, listener := range subscribed {
+			runListener(listener, conn, evt)
+		}
+	})
+}
+
+// Background runs fn on its own goroutine, off the WhatsApp event loop, and
+// tracks it like event listeners
+func Background(fn func()) {
+	inFlight.Add(1)
+	go func() {
+		defer inFlight.Done()
+		fn()
+	}()
+}
+
+// WaitBackground blocks until every listener started by Emit and every
+// function started by Background has returned
+func WaitBackground() {
+	inFlight.Wait()
+}
+
+// runListener calls a listener and recovers from its panics
+func runListener(listener EventListener, conn *IClient, evt *IEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Recovered from %s listener panic: %v\n", evt.Type, r)
+		}
+	}()
+
+	listener(conn, evt)
+}
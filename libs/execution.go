@@ -0,0 +1,80 @@
+package libs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// DefaultCommandTimeout applies to commands that do not declare a Timeout
+const DefaultCommandTimeout = 60 * time.Second
+
+// runningCommand tracks a command in flight so it can be cancelled
+type runningCommand struct {
+	id     types.MessageID
+	chat   types.JID
+	sender types.JID
+	cancel context.CancelFunc
+}
+
+var (
+	rootCtx, rootCancel = context.WithCancel(context.Background())
+
+	running      = make(map[uint64]*runningCommand)
+	runningMutex sync.Mutex
+	runningSeq   uint64
+)
+
+// BeginCommand creates the execution context for a command, bounded by its
+// timeout and by shutdown; the returned function must be called when done
+func BeginCommand(cmd *ICommand, m *IMessage) (context.Context, context.CancelFunc) {
+	timeout := DefaultCommandTimeout
+	if cmd != nil && cmd.Timeout > 0 {
+		timeout = cmd.Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(rootCtx, timeout)
+
+	runningMutex.Lock()
+	runningSeq++
+	id := runningSeq
+	running[id] = &runningCommand{
+		id:     m.Info.ID,
+		chat:   m.Info.Chat,
+		sender: m.Sender.ToNonAD(),
+		cancel: cancel,
+	}
+	runningMutex.Unlock()
+
+	return ctx, func() {
+		runningMutex.Lock()
+		delete(running, id)
+		runningMutex.Unlock()
+		cancel()
+	}
+}
+
+// CancelCommands cancels every other running command of the message sender
+// in the same chat and returns how many were cancelled
+func CancelCommands(m *IMessage) int {
+	runningMutex.Lock()
+	defer runningMutex.Unlock()
+
+	sender := m.Sender.ToNonAD()
+	count := 0
+	for id, cmd := range running {
+		if cmd.id != m.Info.ID && cmd.chat == m.Info.Chat && cmd.sender == sender {
+			cmd.cancel()
+			delete(running, id)
+			count++
+		}
+	}
+	return count
+}
+
+// Shutdown cancels every running command and any command started afterwards
+func Shutdown() {
+	rootCancel()
+}
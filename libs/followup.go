@@ -0,0 +1,122 @@
+package libs
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// FollowUpTTL is how long a sent result set accepts numbered replies
+const FollowUpTTL = 10 * time.Minute
+
+// maxFollowUps bounds how many result sets are remembered at once
+const maxFollowUps = 500
+
+// IFollowUp is a numbered result set sent by the bot. Replying to it with a
+// number runs Command with the matching item as its arguments.
+type IFollowUp struct {
+	Chat    types.JID
+	Command string
+	Items   []string
+	sentAt  time.Time
+}
+
+var (
+	followUps     = make(map[types.MessageID]*IFollowUp)
+	followUpMutex sync.Mutex
+)
+
+// RememberResults stores a result set under the ID of the message that
+// displayed it
+func RememberResults(id types.MessageID, chat types.JID, command string, items []string) {
+	if id == "" || command == "" || len(items) == 0 {
+		return
+	}
+
+	followUpMutex.Lock()
+	defer followUpMutex.Unlock()
+
+	now := time.Now()
+	if len(followUps) >= maxFollowUps {
+		pruneFollowUps(now)
+	}
+
+	followUps[id] = &IFollowUp{
+		Chat:    chat,
+		Command: command,
+		Items:   items,
+		sentAt:  now,
+	}
+}
+
+// LookupResults returns the result set a message replies to and the item it
+// selects, when the message body is a valid item number
+func LookupResults(m *IMessage) (*IFollowUp, string, bool) {
+	if m.Quoted == nil || m.Quoted.GetStanzaID() == "" {
+		return nil, "", false
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(m.Body))
+	if err != nil {
+		return nil, "", false
+	}
+
+	followUpMutex.Lock()
+	defer followUpMutex.Unlock()
+
+	followUp, exists := followUps[m.Quoted.GetStanzaID()]
+	if !exists || followUp.Chat != m.Info.Chat {
+		return nil, "", false
+	}
+
+	if time.Since(followUp.sentAt) > FollowUpTTL {
+		delete(followUps, m.Quoted.GetStanzaID())
+		return nil, "", false
+	}
+
+	if index < 1 || index > len(followUp.Items) {
+		return nil, "", false
+	}
+
+	return followUp, followUp.Items[index-1], true
+}
+
+// ApplyFollowUp rewrites a numbered reply into an invocation of the result
+// set command and reports whether the message was rewritten
+func ApplyFollowUp(m *IMessage) bool {
+	followUp, item, ok := LookupResults(m)
+	if !ok {
+		return false
+	}
+
+	m.Command = followUp.Command
+	if m.Prefix == "" {
+		m.Prefix = DefaultPrefix(m.Info.Chat.String())
+	}
+	m.Text = item
+	m.Args = strings.Fields(item)
+	return true
+}
+
+// pruneFollowUps drops expired result sets, and the oldest ones when the
+// store is still full; the caller holds followUpMutex
+func pruneFollowUps(now time.Time) {
+	var oldestID types.MessageID
+	var oldest time.Time
+	for id, followUp := range followUps {
+		if now.Sub(followUp.sentAt) > FollowUpTTL {
+			delete(followUps, id)
+			continue
+		}
+		if oldestID == "" || followUp.sentAt.Before(oldest) {
+			oldestID, oldest = id, followUp.sentAt
+		}
+	}
+
+	if len(followUps) >= maxFollowUps {
+		delete(followUps, oldestID)
+	}
+}
@@ -0,0 +1,144 @@
+package libs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// GroupCacheTTL is how long fetched group metadata is reused
+const GroupCacheTTL = 5 * time.Minute
+
+// cachedGroup holds group metadata with its fetch time
+type cachedGroup struct {
+	info      *types.GroupInfo
+	fetchedAt time.Time
+}
+
+var (
+	groupCache      = make(map[types.JID]*cachedGroup)
+	groupCacheMutex sync.RWMutex
+)
+
+func init() {
+	// Membership and admin changes make the cached metadata stale
+	On(EventGroupParticipants, func(conn *IClient, evt *IEvent) {
+		InvalidateGroup(evt.Chat)
+	})
+}
+
+// GetGroupInfo returns group metadata, served from cache when fresh
+func (conn *IClient) GetGroupInfo(jid types.JID) (*types.GroupInfo, error) {
+	groupCacheMutex.RLock()
+	cached, ok := groupCache[jid]
+	groupCacheMutex.RUnlock()
+
+	if ok && time.Since(cached.fetchedAt) < GroupCacheTTL {
+		return cached.info, nil
+	}
+
+	if conn == nil || conn.Messenger == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	info, err := conn.Messenger.GetGroupInfo(jid)
+	if err != nil {
+		return nil, err
+	}
+
+	groupCacheMutex.Lock()
+	groupCache[jid] = &cachedGroup{info: info, fetchedAt: time.Now()}
+	groupCacheMutex.Unlock()
+
+	return info, nil
+}
+
+// InvalidateGroup drops the cached metadata of a group
+func InvalidateGroup(jid types.JID) {
+	groupCacheMutex.Lock()
+	defer groupCacheMutex.Unlock()
+
+	delete(groupCache, jid)
+}
+
+// IsGroupAdmin reports whether a user is an admin of a group, matching the
+// participant by phone number or LID
+func (conn *IClient) IsGroupAdmin(group, user types.JID) (bool, error) {
+	info, err := conn.GetGroupInfo(group)
+	if err != nil {
+		return false, err
+	}
+
+	user = user.ToNonAD()
+	for _, participant := range info.Participants {
+		if !participant.IsAdmin && !participant.IsSuperAdmin {
+			continue
+		}
+		if matchesParticipant(participant, user) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsBotGroupAdmin reports whether the bot itself is an admin of a group
+func (conn *IClient) IsBotGroupAdmin(group types.JID) (bool, error) {
+	if conn == nil || conn.Messenger == nil || conn.Messenger.OwnID().IsEmpty() {
+		return false, fmt.Errorf("client is not initialized")
+	}
+
+	if ok, err := conn.IsGroupAdmin(group, conn.Messenger.OwnID()); err != nil || ok {
+		return ok, err
+	}
+
+	if lid := conn.Messenger.OwnLID(); !lid.IsEmpty() {
+		return conn.IsGroupAdmin(group, lid)
+	}
+	return false, nil
+}
+
+// LoadGroupContext fills the group subject and whether the sender and the
+// bot are admins, from a single cached metadata lookup. It may fetch the
+// metadata, so it runs on the worker rather than in the event handler.
+func (m *IMessage) LoadGroupContext() {
+	conn := m.Client
+	if !m.Info.IsGroup || conn == nil || conn.Messenger == nil {
+		return
+	}
+
+	info, err := conn.GetGroupInfo(m.Info.Chat)
+	if err != nil {
+		return
+	}
+
+	sender := m.Sender.ToNonAD()
+	self, selfLID := conn.Messenger.OwnID().ToNonAD(), conn.Messenger.OwnLID().ToNonAD()
+	for _, participant := range info.Participants {
+		if !participant.IsAdmin && !participant.IsSuperAdmin {
+			continue
+		}
+		if matchesParticipant(participant, sender) {
+			m.IsGroupAdmin = true
+		}
+		if matchesParticipant(participant, self) || matchesParticipant(participant, selfLID) {
+			m.IsBotAdmin = true
+		}
+	}
+	m.GroupName = info.Name
+}
+
+// matchesParticipant reports whether a user is the participant, by phone
+// number or LID
+func matchesParticipant(participant types.GroupParticipant, user types.JID) bool {
+	return sameUser(participant.JID, user) || sameUser(participant.PhoneNumber, user) || sameUser(participant.LID, user)
+}
+
+// sameUser compares two JIDs ignoring the device part
+func sameUser(a, b types.JID) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return false
+	}
+	return a.User == b.User && a.Server == b.Server
+}
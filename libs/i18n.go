@@ -0,0 +1,26 @@
+package libs
+
+import (
+	"zumygo/config"
+	"zumygo/locales"
+)
+
+// Lang returns the reply language for the message: the sender's choice,
+// then the chat's, then the configured default
+func (m *IMessage) Lang() string {
+	if m.User != nil && m.User.Language != "" {
+		return m.User.Language
+	}
+	if m.ChatData != nil && m.ChatData.Language != "" {
+		return m.ChatData.Language
+	}
+	if config.Config != nil && config.Config.Language != "" {
+		return config.Config.Language
+	}
+	return locales.Default
+}
+
+// T translates a catalog key into the message language
+func (m *IMessage) T(key string, args ...interface{}) string {
+	return locales.T(m.Lang(), key, args...)
+}
@@ -0,0 +1,63 @@
+package libs
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// identityTimeout bounds a LID store lookup
+const identityTimeout = 5 * time.Second
+
+// ResolvePN returns the phone number JID of a user addressed by LID, as
+// recorded in the LID store. Other JIDs, and LIDs with no known mapping,
+// come back unchanged.
+func (conn *IClient) ResolvePN(jid types.JID) types.JID {
+	if jid.Server != types.HiddenUserServer || conn == nil || conn.Messenger == nil {
+		return jid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), identityTimeout)
+	defer cancel()
+
+	pn, err := conn.Messenger.GetPNForLID(ctx, jid.ToNonAD())
+	if err != nil || pn.IsEmpty() {
+		return jid
+	}
+	pn.Device = jid.Device
+	return pn
+}
+
+// ResolveLID returns the LID of a user addressed by phone number, or the JID
+// unchanged when it is not a phone number JID or has no known LID
+func (conn *IClient) ResolveLID(jid types.JID) types.JID {
+	if jid.Server != types.DefaultUserServer || conn == nil || conn.Messenger == nil {
+		return jid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), identityTimeout)
+	defer cancel()
+
+	lid, err := conn.Messenger.GetLIDForPN(ctx, jid.ToNonAD())
+	if err != nil || lid.IsEmpty() {
+		return jid
+	}
+	lid.Device = jid.Device
+	return lid
+}
+
+// SameUser reports whether two JIDs belong to the same user, whichever of
+// LID or phone number each is addressed by
+func (conn *IClient) SameUser(a, b types.JID) bool {
+	return conn.ResolvePN(a).ToNonAD() == conn.ResolvePN(b).ToNonAD()
+}
+
+// resolveSender returns the phone number JID of a message sender: the
+// alternate address of LID-addressed messages, or a LID store lookup
+func resolveSender(conn *IClient, info types.MessageInfo) types.JID {
+	if info.Sender.Server == types.HiddenUserServer && info.SenderAlt.Server == types.DefaultUserServer {
+		return info.SenderAlt
+	}
+	return conn.ResolvePN(info.Sender)
+}
@@ -0,0 +1,206 @@
+package libs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"zumygo/config"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// IButton is a reply button. Its ID comes back when tapped and runs as a
+// command, with or without a prefix, such as "menu group".
+type IButton struct {
+	ID   string
+	Text string
+}
+
+// IListRow is one selectable row of a list; ID works as for IButton
+type IListRow struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// IListSection groups list rows under a title
+type IListSection struct {
+	Title string
+	Rows  []IListRow
+}
+
+// IFlowButton is a native flow button: a name such as quick_reply, cta_url,
+// cta_copy or single_select with its JSON parameters
+type IFlowButton struct {
+	Name   string
+	Params string
+}
+
+// QuickReplyButton returns a native flow button answering with id
+func QuickReplyButton(id, text string) IFlowButton {
+	return flowButton("quick_reply", map[string]string{"display_text": text, "id": id})
+}
+
+// URLButton returns a native flow button opening url
+func URLButton(text, url string) IFlowButton {
+	return flowButton("cta_url", map[string]string{"display_text": text, "url": url, "merchant_url": url})
+}
+
+// CopyButton returns a native flow button copying code to the clipboard
+func CopyButton(text, code string) IFlowButton {
+	return flowButton("cta_copy", map[string]string{"display_text": text, "copy_code": code})
+}
+
+// SelectButton returns a native flow button opening a list of rows
+func SelectButton(title string, sections []IListSection) IFlowButton {
+	type row struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		ID          string `json:"id"`
+	}
+	type section struct {
+		Title string `json:"title,omitempty"`
+		Rows  []row  `json:"rows"`
+	}
+
+	params := struct {
+		Title    string    `json:"title"`
+		Sections []section `json:"sections"`
+	}{Title: title}
+	for _, s := range sections {
+		rows := make([]row, 0, len(s.Rows))
+		for _, r := range s.Rows {
+			rows = append(rows, row{Title: r.Title, Description: r.Description, ID: r.ID})
+		}
+		params.Sections = append(params.Sections, section{Title: s.Title, Rows: rows})
+	}
+	return flowButton("single_select", params)
+}
+
+// flowButton encodes the parameters of a native flow button
+func flowButton(name string, params interface{}) IFlowButton {
+	data, _ := json.Marshal(params)
+	return IFlowButton{Name: name, Params: string(data)}
+}
+
+// Buttons sends the text with up to three reply buttons under it
+func (b *IMessageBuilder) Buttons(footer string, buttons ...IButton) *IMessageBuilder {
+	b.interactive = func(text string, info *waE2E.ContextInfo) (*waE2E.Message, error) {
+		if len(buttons) == 0 {
+			return nil, fmt.Errorf("no buttons provided")
+		}
+
+		var items []*waE2E.ButtonsMessage_Button
+		for _, button := range buttons {
+			items = append(items, &waE2E.ButtonsMessage_Button{
+				ButtonID:   proto.String(button.ID),
+				ButtonText: &waE2E.ButtonsMessage_Button_ButtonText{DisplayText: proto.String(button.Text)},
+				Type:       waE2E.ButtonsMessage_Button_RESPONSE.Enum(),
+			})
+		}
+
+		return &waE2E.Message{
+			ButtonsMessage: &waE2E.ButtonsMessage{
+				ContentText: proto.String(text),
+				FooterText:  proto.String(footer),
+				HeaderType:  waE2E.ButtonsMessage_EMPTY.Enum(),
+				Buttons:     items,
+				ContextInfo: info,
+			},
+		}, nil
+	}
+	return b
+}
+
+// List sends the text with a button opening a single-select list
+func (b *IMessageBuilder) List(footer, buttonText string, sections ...IListSection) *IMessageBuilder {
+	b.interactive = func(text string, info *waE2E.ContextInfo) (*waE2E.Message, error) {
+		if len(sections) == 0 {
+			return nil, fmt.Errorf("no list sections provided")
+		}
+
+		var items []*waE2E.ListMessage_Section
+		for _, section := range sections {
+			var rows []*waE2E.ListMessage_Row
+			for _, row := range section.Rows {
+				rows = append(rows, &waE2E.ListMessage_Row{
+					RowID:       proto.String(row.ID),
+					Title:       proto.String(row.Title),
+					Description: proto.String(row.Description),
+				})
+			}
+			items = append(items, &waE2E.ListMessage_Section{Title: proto.String(section.Title), Rows: rows})
+		}
+
+		return &waE2E.Message{
+			ListMessage: &waE2E.ListMessage{
+				Description: proto.String(text),
+				FooterText:  proto.String(footer),
+				ButtonText:  proto.String(buttonText),
+				ListType:    waE2E.ListMessage_SINGLE_SELECT.Enum(),
+				Sections:    items,
+				ContextInfo: info,
+			},
+		}, nil
+	}
+	return b
+}
+
+// NativeFlow sends the text with native flow buttons, the interactive
+// format current WhatsApp clients render
+func (b *IMessageBuilder) NativeFlow(footer string, buttons ...IFlowButton) *IMessageBuilder {
+	b.interactive = func(text string, info *waE2E.ContextInfo) (*waE2E.Message, error) {
+		if len(buttons) == 0 {
+			return nil, fmt.Errorf("no buttons provided")
+		}
+
+		var items []*waE2E.InteractiveMessage_NativeFlowMessage_NativeFlowButton
+		for _, button := range buttons {
+			items = append(items, &waE2E.InteractiveMessage_NativeFlowMessage_NativeFlowButton{
+				Name:             proto.String(button.Name),
+				ButtonParamsJSON: proto.String(button.Params),
+			})
+		}
+
+		return &waE2E.Message{
+			ViewOnceMessage: &waE2E.FutureProofMessage{
+				Message: &waE2E.Message{
+					InteractiveMessage: &waE2E.InteractiveMessage{
+						Body:   &waE2E.InteractiveMessage_Body{Text: proto.String(text)},
+						Footer: &waE2E.InteractiveMessage_Footer{Text: proto.String(footer)},
+						InteractiveMessage: &waE2E.InteractiveMessage_NativeFlowMessage_{
+							NativeFlowMessage: &waE2E.InteractiveMessage_NativeFlowMessage{
+								Buttons:        items,
+								MessageVersion: proto.Int32(1),
+							},
+						},
+						ContextInfo: info,
+					},
+				},
+			},
+		}, nil
+	}
+	return b
+}
+
+// InteractiveEnabled reports whether menus and results are sent as native
+// flow messages rather than plain text
+func InteractiveEnabled() bool {
+	return config.Config != nil && config.Config.Interactive
+}
+
+// ReplySelect replies with text and a button listing rows to pick from, or
+// with the text alone when interactive messages are off
+func (m *IMessage) ReplySelect(text, title string, rows []IListRow) (whatsmeow.SendResponse, error) {
+	if !InteractiveEnabled() || len(rows) == 0 || m.Client == nil || m.Client.Messenger == nil {
+		return m.Reply(text)
+	}
+
+	return m.Client.Message(m.Info.Chat).
+		Text(text).
+		NativeFlow("", SelectButton(title, []IListSection{{Rows: rows}})).
+		Quote(m).
+		Send(context.Background())
+}
@@ -0,0 +1,204 @@
+package libs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"zumygo/database"
+	"zumygo/helpers"
+)
+
+// Job kinds
+const (
+	JobMessage = "message" // Send the text to the chat
+	JobCommand = "command" // Run the text as a command from the job creator
+)
+
+// maxJobWait bounds how long the runner sleeps between checks
+const maxJobWait = time.Minute
+
+var (
+	ErrJobPast    = errors.New("job time is in the past")
+	ErrJobNever   = errors.New("cron expression never matches")
+	ErrJobNoStore = errors.New("database is not initialized")
+)
+
+// jobsWake interrupts the runner sleep when jobs change
+var jobsWake = make(chan struct{}, 1)
+
+// ScheduleJob validates a job, fills its ID and first run and stores it.
+// Repeating jobs set Cron; one-shot jobs set RunAt.
+func ScheduleJob(job database.Job) (database.Job, error) {
+	if database.DB == nil {
+		return job, ErrJobNoStore
+	}
+
+	now := time.Now()
+	if job.Cron != "" {
+		schedule, err := ParseCron(job.Cron)
+		if err != nil {
+			return job, err
+		}
+		next := schedule.Next(now)
+		if next.IsZero() {
+			return job, ErrJobNever
+		}
+		job.NextRun = next.Unix()
+	} else {
+		if job.RunAt <= now.Unix() {
+			return job, ErrJobPast
+		}
+		job.NextRun = job.RunAt
+	}
+
+	if job.Kind == "" {
+		job.Kind = JobMessage
+	}
+	job.ID = newJobID()
+	job.CreatedAt = now.Unix()
+
+	database.DB.AddJob(&job)
+	persistJobs()
+	wakeJobs()
+	return job, nil
+}
+
+// CancelJob removes a job and reports whether it existed
+func CancelJob(id string) bool {
+	if database.DB == nil || !database.DB.RemoveJob(id) {
+		return false
+	}
+	persistJobs()
+	wakeJobs()
+	return true
+}
+
+// ListJobs returns every job ordered by next run
+func ListJobs() []database.Job {
+	if database.DB == nil {
+		return nil
+	}
+
+	jobs := database.DB.GetJobs()
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].NextRun < jobs[j].NextRun
+	})
+	return jobs
+}
+
+// RunJobs fires due jobs until shutdown. Jobs missed while the bot was
+// offline fire once on start; repeating jobs then continue from now.
+func RunJobs(fire func(job database.Job)) {
+	for {
+		next := runDueJobs(time.Now(), fire)
+
+		wait := maxJobWait
+		if !next.IsZero() {
+			if until := time.Until(next); until < wait {
+				wait = until
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-rootCtx.Done():
+			timer.Stop()
+			return
+		case <-jobsWake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// runDueJobs fires every job due at now, reschedules or removes it, and
+// returns the earliest upcoming run
+func runDueJobs(now time.Time, fire func(job database.Job)) time.Time {
+	if database.DB == nil {
+		return time.Time{}
+	}
+
+	var earliest time.Time
+	for _, job := range database.DB.GetJobs() {
+		if job.NextRun > now.Unix() {
+			if next := time.Unix(job.NextRun, 0); earliest.IsZero() || next.Before(earliest) {
+				earliest = next
+			}
+			continue
+		}
+
+		go fireJob(fire, job)
+
+		if job.Cron == "" {
+			database.DB.RemoveJob(job.ID)
+			persistJobs()
+			continue
+		}
+
+		schedule, err := ParseCron(job.Cron)
+		var next time.Time
+		if err == nil {
+			next = schedule.Next(now)
+		}
+		if next.IsZero() {
+			database.DB.RemoveJob(job.ID)
+			persistJobs()
+			continue
+		}
+
+		database.DB.SetJobRun(job.ID, now.Unix(), next.Unix())
+		persistJobs()
+		if earliest.IsZero() || next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest
+}
+
+// fireJob runs a job and recovers from its panics
+func fireJob(fire func(job database.Job), job database.Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			helpers.Logger{}.Error(fmt.Sprintf("Recovered from job %s panic: %v", job.ID, r))
+		}
+	}()
+	fire(job)
+}
+
+// wakeJobs makes the runner recompute its next wake up
+func wakeJobs() {
+	select {
+	case jobsWake <- struct{}{}:
+	default:
+	}
+}
+
+// persistJobs saves the database right away so job changes survive a crash
+func persistJobs() {
+	if err := database.DB.ForceSave(); err != nil {
+		helpers.Logger{}.Error(fmt.Sprintf("Failed to save jobs: %v", err))
+	}
+}
+
+// newJobID returns a short random ID that is easy to type and not used by
+// another job
+func newJobID() string {
+	for {
+		id := randomJobID()
+		if !database.DB.HasJob(id) {
+			return id
+		}
+	}
+}
+
+// randomJobID returns six random hex digits
+func randomJobID() string {
+	buf := make([]byte, 3)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%06x", time.Now().UnixNano()&0xffffff)
+	}
+	return hex.EncodeToString(buf)
+}
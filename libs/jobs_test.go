@@ -0,0 +1,62 @@
+package libs
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	"zumygo/database"
+)
+
+func TestRunDueJobs(t *testing.T) {
+	prev := database.DB
+	t.Cleanup(func() { database.DB = prev })
+	path := filepath.Join(t.TempDir(), "database.json")
+	if _, err := database.InitDatabase(path); err != nil {
+		t.Fatalf("init database: %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 10, 30, 0, 0, time.Local)
+	database.DB.AddJob(&database.Job{ID: "once", NextRun: now.Add(-time.Minute).Unix()})
+	database.DB.AddJob(&database.Job{ID: "cron", Cron: "0 * * * *", NextRun: now.Unix()})
+	database.DB.AddJob(&database.Job{ID: "later", NextRun: now.Add(10 * time.Minute).Unix()})
+
+	fired := make(chan string, 3)
+	next := runDueJobs(now, func(job database.Job) { fired <- job.ID })
+
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-fired:
+			got[id] = true
+		case <-time.After(time.Second):
+			t.Fatalf("fired %v, want once and cron", got)
+		}
+	}
+	if !got["once"] || !got["cron"] {
+		t.Errorf("fired %v, want once and cron", got)
+	}
+
+	if want := now.Add(10 * time.Minute); !next.Equal(want) {
+		t.Errorf("next wake = %v, want %v", next, want)
+	}
+
+	jobs := map[string]database.Job{}
+	for _, job := range database.DB.GetJobs() {
+		jobs[job.ID] = job
+	}
+	if _, ok := jobs["once"]; ok {
+		t.Errorf("one-shot job was not removed")
+	}
+	if job := jobs["cron"]; job.LastRun != now.Unix() || job.NextRun != now.Add(30*time.Minute).Unix() {
+		t.Errorf("cron job = %+v, want rescheduled to 11:00", job)
+	}
+
+	// The changes are on disk before the next save tick
+	reloaded, err := database.InitDatabase(path)
+	if err != nil {
+		t.Fatalf("reload database: %v", err)
+	}
+	if saved := reloaded.GetJobs(); len(saved) != 2 || reloaded.HasJob("once") || !reloaded.HasJob("cron") {
+		t.Errorf("saved jobs = %v, want cron and later", saved)
+	}
+}
@@ -0,0 +1,328 @@
+package libs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"zumygo/config"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultMaxDownloadSize applies when the config does not set one
+	DefaultMaxDownloadSize = 100 << 20
+
+	// DefaultDownloadTimeout bounds DownloadTo, which takes no context
+	DefaultDownloadTimeout = 2 * time.Minute
+
+	// MediaRetryTimeout bounds the wait for the sender to upload expired media again
+	MediaRetryTimeout = 30 * time.Second
+
+	// TempMediaTTL is how long temporary media files may outlive their command
+	TempMediaTTL = time.Hour
+)
+
+var (
+	ErrNoMedia       = errors.New("message has no media")
+	ErrMediaTooLarge = errors.New("media exceeds the maximum download size")
+)
+
+// mediaExtensions maps the usual WhatsApp MIME types to file extensions
+var mediaExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"image/gif":       ".gif",
+	"video/mp4":       ".mp4",
+	"video/3gpp":      ".3gp",
+	"video/quicktime": ".mov",
+	"audio/ogg":       ".ogg",
+	"audio/mpeg":      ".mp3",
+	"audio/mp4":       ".m4a",
+	"audio/aac":       ".aac",
+	"audio/wav":       ".wav",
+	"application/pdf": ".pdf",
+	"application/zip": ".zip",
+	"text/plain":      ".txt",
+}
+
+// IMedia is downloaded media with what is known about its format
+type IMedia struct {
+	Data     []byte
+	Mimetype string
+	Ext      string // Extension with the leading dot
+	Kind     string // image, video, audio, document or sticker
+	FileName string // Original name, documents only
+}
+
+// Download fetches the message media: the quoted media when the message
+// quotes one, otherwise its own, as in m.Media
+func (m *IMessage) Download(ctx context.Context) (*IMedia, error) {
+	if m.Media == nil {
+		return nil, ErrNoMedia
+	}
+	if m.QuotedMedia != nil && m.Media == m.QuotedMedia {
+		return m.DownloadQuoted(ctx)
+	}
+	return downloadMedia(ctx, m.Client, m.Media, m.Info)
+}
+
+// DownloadQuoted fetches the media of the quoted message
+func (m *IMessage) DownloadQuoted(ctx context.Context) (*IMedia, error) {
+	if m.QuotedMedia == nil {
+		return nil, ErrNoMedia
+	}
+
+	info := types.MessageInfo{
+		MessageSource: types.MessageSource{
+			Chat:    m.Info.Chat,
+			Sender:  m.QuotedSender,
+			IsGroup: m.Info.IsGroup,
+		},
+		ID: m.Quoted.GetStanzaID(),
+	}
+	if m.Client != nil && m.Client.Messenger != nil {
+		info.IsFromMe = m.QuotedSender.User == m.Client.Messenger.OwnID().User
+	}
+	return downloadMedia(ctx, m.Client, m.QuotedMedia, info)
+}
+
+// DownloadTo saves the message media to path and returns the written file.
+// An empty path or a directory gets a generated name with the detected
+// extension; the file only appears once it is complete.
+func (m *IMessage) DownloadTo(path string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultDownloadTimeout)
+	defer cancel()
+
+	media, err := m.Download(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		path = tempMediaDir()
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, fmt.Sprintf("%s%s", m.Info.ID, media.Ext))
+	}
+
+	if err := writeFileAtomic(path, media.Data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// SaveTemp writes the media to a temporary file for tools that need a path.
+// Call cleanup when done; leftovers are removed after TempMediaTTL anyway.
+func (media *IMedia) SaveTemp() (path string, cleanup func(), err error) {
+	file, err := os.CreateTemp(tempMediaDir(), "media-*"+media.Ext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	path = file.Name()
+	cleanup = func() { os.Remove(path) }
+
+	if _, err := file.Write(media.Data); err != nil {
+		file.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// MaxDownloadSize returns the configured download limit in bytes
+func MaxDownloadSize() int64 {
+	if config.Config != nil && config.Config.MaxDownloadSize > 0 {
+		return int64(config.Config.MaxDownloadSize) << 20
+	}
+	return DefaultMaxDownloadSize
+}
+
+// MediaExtension returns the file extension for a MIME type, falling back to
+// the file name and then to .bin
+func MediaExtension(mimetype, fileName string) string {
+	base := strings.TrimSpace(strings.SplitN(mimetype, ";", 2)[0])
+	if ext, ok := mediaExtensions[base]; ok {
+		return ext
+	}
+	if ext := filepath.Ext(fileName); ext != "" {
+		return strings.ToLower(ext)
+	}
+	if exts, err := mime.ExtensionsByType(base); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
+
+// downloadMedia downloads media within the size limit, asking the sender to
+// upload it again once when the media URL has expired
+func downloadMedia(ctx context.Context, conn *IClient, media whatsmeow.DownloadableMessage, info types.MessageInfo) (*IMedia, error) {
+	if conn == nil || conn.Messenger == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	limit := MaxDownloadSize()
+	if sized, ok := media.(interface{ GetFileLength() uint64 }); ok && int64(sized.GetFileLength()) > limit {
+		return nil, ErrMediaTooLarge
+	}
+
+	data, err := conn.Messenger.Download(ctx, media)
+	if isExpiredMedia(err) && info.ID != "" {
+		retryCtx, cancel := context.WithTimeout(ctx, MediaRetryTimeout)
+		path, retryErr := conn.Messenger.RequestMediaRetry(retryCtx, info, media.GetMediaKey())
+		cancel()
+		if retryErr != nil {
+			return nil, fmt.Errorf("%w (media retry: %v)", err, retryErr)
+		}
+		data, err = conn.Messenger.Download(ctx, withDirectPath(media, path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrMediaTooLarge
+	}
+
+	result := &IMedia{Data: data, Kind: mediaKind(media)}
+	if typed, ok := media.(interface{ GetMimetype() string }); ok {
+		result.Mimetype = typed.GetMimetype()
+	}
+	if document, ok := media.(*waE2E.DocumentMessage); ok {
+		result.FileName = document.GetFileName()
+	}
+	if result.Mimetype == "" {
+		result.Mimetype = http.DetectContentType(data)
+	}
+	result.Ext = MediaExtension(result.Mimetype, result.FileName)
+	return result, nil
+}
+
+// isExpiredMedia reports whether a download failed because the media URL
+// is no longer valid
+func isExpiredMedia(err error) bool {
+	return errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith403) ||
+		errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith404) ||
+		errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith410)
+}
+
+// withDirectPath returns a copy of the media pointing at a new direct path
+func withDirectPath(media whatsmeow.DownloadableMessage, path string) whatsmeow.DownloadableMessage {
+	message, ok := media.(proto.Message)
+	if !ok {
+		return media
+	}
+
+	switch clone := proto.Clone(message).(type) {
+	case *waE2E.ImageMessage:
+		clone.DirectPath = proto.String(path)
+		return clone
+	case *waE2E.VideoMessage:
+		clone.DirectPath = proto.String(path)
+		return clone
+	case *waE2E.AudioMessage:
+		clone.DirectPath = proto.String(path)
+		return clone
+	case *waE2E.DocumentMessage:
+		clone.DirectPath = proto.String(path)
+		return clone
+	case *waE2E.StickerMessage:
+		clone.DirectPath = proto.String(path)
+		return clone
+	}
+	return media
+}
+
+// mediaKind names the media type of a downloadable message
+func mediaKind(media whatsmeow.DownloadableMessage) string {
+	switch media.(type) {
+	case *waE2E.ImageMessage:
+		return "image"
+	case *waE2E.VideoMessage:
+		return "video"
+	case *waE2E.AudioMessage:
+		return "audio"
+	case *waE2E.DocumentMessage:
+		return "document"
+	case *waE2E.StickerMessage:
+		return "sticker"
+	}
+	return ""
+}
+
+// writeFileAtomic writes through a temporary file in the same directory so
+// readers never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return err
+	}
+	tempPath := file.Name()
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+var tempMediaOnce sync.Once
+
+// tempMediaDir returns the directory for temporary media, pruning files
+// older than TempMediaTTL in the background
+func tempMediaDir() string {
+	dir := filepath.Join(os.TempDir(), "zumygo-media")
+	tempMediaOnce.Do(func() {
+		os.MkdirAll(dir, 0755)
+		go func() {
+			for {
+				pruneTempMedia(dir, time.Now().Add(-TempMediaTTL))
+				time.Sleep(TempMediaTTL / 2)
+			}
+		}()
+	})
+	return dir
+}
+
+// isTempMedia reports whether a file lives in the temporary media directory
+func isTempMedia(path string) bool {
+	abs, err := filepath.Abs(path)
+	return err == nil && filepath.Dir(abs) == tempMediaDir()
+}
+
+// pruneTempMedia removes temporary media last modified before cutoff
+func pruneTempMedia(dir string, cutoff time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() && info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
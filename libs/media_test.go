@@ -0,0 +1,19 @@
+package libs
+
+import "testing"
+
+func TestMediaExtension(t *testing.T) {
+	cases := []struct {
+		mimetype, fileName, want string
+	}{
+		{"image/jpeg", "", ".jpg"},
+		{"audio/ogg; codecs=opus", "", ".ogg"},
+		{"application/octet-stream", "Archive.TAR", ".tar"},
+		{"", "", ".bin"},
+	}
+	for _, c := range cases {
+		if got := MediaExtension(c.mimetype, c.fileName); got != c.want {
+			t.Errorf("MediaExtension(%q, %q) = %q, want %q", c.mimetype, c.fileName, got, c.want)
+		}
+	}
+}
@@ -5,18 +5,22 @@ import (
 	"fmt"
 	"zumygo/helpers"
 	"zumygo/config"
-	"regexp"
 	"strings"
 
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	waTypes "go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
-	"google.golang.org/protobuf/proto"
 )
 
-// Compile regex pattern once for better performance
-var nonDigitRegex = regexp.MustCompile(`\D+`)
+// commandKinds are the message kinds whose text is parsed for a command
+var commandKinds = map[string]bool{
+	helpers.KindText:     true,
+	helpers.KindImage:    true,
+	helpers.KindVideo:    true,
+	helpers.KindPTV:      true,
+	helpers.KindDocument: true,
+}
 
 func SerializeMessage(mess *events.Message, conn *IClient) *IMessage {
 	if mess == nil {
@@ -26,64 +30,77 @@ func SerializeMessage(mess *events.Message, conn *IClient) *IMessage {
 	var media whatsmeow.DownloadableMessage
 	var text string
 	var args []string
-	var owner []string
 	var isOwner = false
 	var isMedia string
 	var sender waTypes.JID
 
-	if mess.Info.AddressingMode == "lid" {
-		sender = mess.Info.SenderAlt
-	} else {
-		sender = mess.Info.Sender
-	}
+	// Users are identified by phone number, whether the message is
+	// addressed by LID or not
+	sender = resolveSender(conn, mess.Info)
 
+	// Read text and kind before unwrapping, edits included
+	content := helpers.ExtractContent(mess.Message)
+	setting := mess.Message.GetProtocolMessage()
+	viewOnce := mess.IsViewOnce || helpers.IsViewOnce(mess.Message)
 	mess.Message = helpers.ParseMessage(mess)
-	body := helpers.GetTextMessage(mess)
+	body := content.Text
 	
-	// Validate body before processing
-	if body == "" {
-		body = ""
+	// Only typed text and media captions may run commands; poll questions,
+	// contact names and the like keep their text for logging only
+	var input string
+	if commandKinds[content.Kind] {
+		input = body
 	}
 	
-	// Safe command extraction with multi-prefix support
-	parts := strings.Split(body, " ")
+	// A tapped button or list row carries the command it was sent with
+	selected := helpers.GetSelectedID(mess.Message)
+	if selected != "" {
+		body, input = selected, selected
+	}
+	
+	// A leading bot mention works as a prefix in every chat
+	chatID := mess.Info.Chat.String()
+	mention, hasMention := botMention(conn, input)
+	if hasMention {
+		input = strings.TrimSpace(strings.TrimPrefix(input, mention))
+		body = input
+	}
+	
+	// Safe command extraction with per-chat prefix support
+	parts := strings.Split(input, " ")
 	var command string
 	var hasPrefix bool
 	var prefix string
 	
 	if len(parts) > 0 {
-		command = strings.ToLower(parts[0])
-		// Check if command has a valid prefix
-		prefix, hasPrefix = ExtractPrefix(command)
+		command = parts[0]
+		// Check if command has a valid prefix, before lowercasing so
+		// prefixes with capitals still match
+		prefix, hasPrefix = ExtractPrefix(chatID, command)
 		if hasPrefix {
 			// Remove prefix from command
 			command = strings.TrimSpace(strings.TrimPrefix(command, prefix))
-		} else {
-			// If no prefix found, don't treat as command
+		}
+		command = strings.ToLower(command)
+		
+		if hasMention {
+			prefix, hasPrefix = mention+" ", true
+		} else if !hasPrefix && selected != "" && HasCommand(command) {
+			// Selected IDs may name a command without its prefix
+			prefix, hasPrefix = DefaultPrefix(chatID), true
+		} else if !hasPrefix && (!IsNoPrefixChat(chatID) || !HasCommand(command)) {
+			// If no prefix found, don't treat as command unless the chat
+			// runs commands without one
 			command = ""
 		}
 	}
 	
+	// Compare whole normalized numbers; LIDs never match an owner
 	if config.Config != nil {
-		owner = config.Config.Owner
-	}
-
-	for _, v := range owner {
-		if v != "" && strings.Contains(nonDigitRegex.ReplaceAllString(v, ""), sender.ToNonAD().User) {
-			isOwner = true
-			break
-		}
+		isOwner = config.Config.IsOwner(sender.ToNonAD().String())
 	}
 
-	// Safe mention removal
-	if conn != nil && conn.WA != nil && conn.WA.Store != nil && conn.WA.Store.ID != nil {
-		botID := "@" + conn.WA.Store.ID.ToNonAD().User
-		if strings.HasPrefix(body, botID) {
-			body = strings.Trim(strings.Replace(body, botID, "", 1), " ")
-		}
-	}
-
-	if hasPrefix && HasCommand(command) {
+	if command != "" && HasCommand(command) {
 		if len(parts) > 1 {
 			text = strings.Join(parts[1:], ` `)
 		}
@@ -116,56 +133,104 @@ func SerializeMessage(mess *events.Message, conn *IClient) *IMessage {
 		if contextInfo != nil {
 			expiration = contextInfo.GetExpiration()
 			quoted = contextInfo
+			rememberExpiration(mess.Info.Chat, expiration)
 		}
 	}
+	if setting.GetType() == waE2E.ProtocolMessage_EPHEMERAL_SETTING {
+		rememberExpiration(mess.Info.Chat, setting.GetEphemeralExpiration())
+	}
 
-	return &IMessage{
-		Info:       mess.Info,
-		Sender:     sender,
-		IsOwner:    isOwner,
-		Body:       body,
-		Text:       text,
-		Args:       args,
-		Command:    command,
-		Message:    mess.Message,
-		IsMedia:    isMedia,
-		Media:      media,
-		Expiration: expiration,
-		Quoted:     quoted,
-		Client:     conn,
-		Reply: func(text string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
-			if conn == nil || conn.WA == nil {
-				fmt.Printf("ERROR: Client is not initialized for Reply\n")
-				return whatsmeow.SendResponse{}, fmt.Errorf("client is not initialized")
-			}
-			
-			var Expiration uint32
-			if mess.Message != nil {
-				contextInfo := helpers.GetContextInfo(mess.Message)
-				if contextInfo != nil {
-					Expiration = contextInfo.GetExpiration()
-				}
-			}
-			
-			response, err := conn.SendText(mess.Info.Chat, text, &waE2E.ContextInfo{
-				StanzaID:      &mess.Info.ID,
-				Participant:   proto.String(mess.Info.Sender.String()),
-				QuotedMessage: mess.Message,
-				Expiration:    &Expiration,
-			}, opts...)
-			
-			if err != nil {
-				fmt.Printf("ERROR: Failed to send reply: %v\n", err)
+	// Mentioned users and the quoted message details
+	var mentions []waTypes.JID
+	var quotedSender waTypes.JID
+	var quotedText string
+	var quotedMedia whatsmeow.DownloadableMessage
+	
+	for _, raw := range quoted.GetMentionedJID() {
+		if jid, err := waTypes.ParseJID(raw); err == nil {
+			mentions = append(mentions, jid)
+		}
+	}
+	
+	if quotedMsg != nil {
+		quotedText = helpers.GetMessageText(quotedMsg)
+		quotedMedia = helpers.GetMediaMessage(quotedMsg)
+		if participant := quoted.GetParticipant(); participant != "" {
+			if jid, err := waTypes.ParseJID(participant); err == nil {
+				quotedSender = jid
 			}
-			
-			return response, err
-		},
-		React: func(emoji string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
-			if conn == nil || conn.WA == nil {
-				return whatsmeow.SendResponse{}, fmt.Errorf("client is not initialized")
+		} else if !mess.Info.IsGroup {
+			// Private chats only hold the bot and the other party
+			quotedSender = mess.Info.Chat
+			if conn != nil && conn.Messenger != nil && sentByBot(waTypes.MessageID(quoted.GetStanzaID())) {
+				quotedSender = conn.Messenger.OwnID().ToNonAD()
 			}
-			
-			return conn.WA.SendMessage(context.Background(), mess.Info.Chat, conn.WA.BuildReaction(mess.Info.Chat, mess.Info.Sender, mess.Info.ID, emoji), opts...)
-		},
+		}
+	}
+
+	m := &IMessage{
+		Info:         mess.Info,
+		Sender:       sender,
+		IsOwner:      isOwner,
+		Body:         body,
+		Text:         text,
+		Args:         args,
+		Command:      command,
+		Prefix:       prefix,
+		Message:      mess.Message,
+		Kind:         content.Kind,
+		IsEdited:     content.Edited,
+		IsViewOnce:   viewOnce,
+		IsMedia:      isMedia,
+		Media:        media,
+		Expiration:   expiration,
+		Quoted:       quoted,
+		Mentions:     mentions,
+		QuotedSender: quotedSender,
+		QuotedText:   quotedText,
+		QuotedMedia:  quotedMedia,
+		PushName:     mess.Info.PushName,
+		Client:       conn,
+	}
+	m.Reply = func(text string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+		if conn == nil || conn.Messenger == nil {
+			fmt.Printf("ERROR: Client is not initialized for Reply\n")
+			return whatsmeow.SendResponse{}, fmt.Errorf("client is not initialized")
+		}
+		
+		response, err := conn.Message(mess.Info.Chat).Text(text).Quote(m).Extra(opts...).Send(context.Background())
+		if err != nil {
+			fmt.Printf("ERROR: Failed to send reply: %v\n", err)
+		}
+		
+		return response, err
+	}
+	m.React = func(emoji string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+		if conn == nil || conn.Messenger == nil {
+			return whatsmeow.SendResponse{}, fmt.Errorf("client is not initialized")
+		}
+		
+		return conn.Messenger.SendMessage(context.Background(), mess.Info.Chat, conn.Messenger.BuildReaction(mess.Info.Chat, mess.Info.Sender, mess.Info.ID, emoji), opts...)
+	}
+	return m
+}
+
+// botMention returns the bot mention the body starts with, matching both the
+// phone number and the LID forms of the bot account
+func botMention(conn *IClient, body string) (string, bool) {
+	if conn == nil || conn.Messenger == nil || conn.Messenger.OwnID().IsEmpty() {
+		return "", false
+	}
+	
+	candidates := []string{"@" + conn.Messenger.OwnID().ToNonAD().User}
+	if lid := conn.Messenger.OwnLID(); !lid.IsEmpty() {
+		candidates = append(candidates, "@"+lid.ToNonAD().User)
+	}
+	
+	for _, mention := range candidates {
+		if strings.HasPrefix(body, mention) {
+			return mention, true
+		}
 	}
-}
\ No newline at end of file
+	return "", false
+}
@@ -0,0 +1,171 @@
+package libs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waMmsRetry"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Messenger is everything commands need from the WhatsApp connection.
+// The live implementation wraps a whatsmeow client; tests use a fake.
+type Messenger interface {
+	// SendMessage sends a message to a chat
+	SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
+	// BuildReaction builds a reaction to a message; an empty emoji removes it
+	BuildReaction(chat, sender types.JID, id types.MessageID, emoji string) *waE2E.Message
+	// Upload encrypts and uploads media, ready to be attached to a message
+	Upload(ctx context.Context, data []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error)
+	// UploadReader is Upload streaming the media from a reader, such as a
+	// file, instead of holding it in memory
+	UploadReader(ctx context.Context, data io.Reader, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error)
+	// Download fetches and decrypts the media of a message
+	Download(ctx context.Context, media whatsmeow.DownloadableMessage) ([]byte, error)
+	// RequestMediaRetry asks the sender phone to upload expired media again
+	// and returns its new direct path
+	RequestMediaRetry(ctx context.Context, info types.MessageInfo, mediaKey []byte) (string, error)
+	// GetGroupInfo fetches group metadata from the server
+	GetGroupInfo(jid types.JID) (*types.GroupInfo, error)
+	// MarkRead sends read receipts for messages
+	MarkRead(ids []types.MessageID, timestamp time.Time, chat, sender types.JID) error
+	// SetStatusMessage updates the profile about text
+	SetStatusMessage(status string) error
+	// OwnID returns the bot phone number JID, empty before pairing
+	OwnID() types.JID
+	// OwnLID returns the bot LID, empty when unknown
+	OwnLID() types.JID
+	// GetPNForLID returns the phone number JID of a LID, empty when unknown
+	GetPNForLID(ctx context.Context, lid types.JID) (types.JID, error)
+	// GetLIDForPN returns the LID of a phone number JID, empty when unknown
+	GetLIDForPN(ctx context.Context, pn types.JID) (types.JID, error)
+}
+
+var (
+	mediaRetries    = make(map[types.MessageID]chan *events.MediaRetry)
+	mediaRetryMutex sync.Mutex
+)
+
+// DeliverMediaRetry hands a media retry notification to the download waiting
+// for it and reports whether one was
+func DeliverMediaRetry(evt *events.MediaRetry) bool {
+	mediaRetryMutex.Lock()
+	waiter, ok := mediaRetries[evt.MessageID]
+	mediaRetryMutex.Unlock()
+	if !ok {
+		return false
+	}
+
+	select {
+	case waiter <- evt:
+	default:
+	}
+	return true
+}
+
+// waMessenger is the Messenger backed by a live whatsmeow client
+type waMessenger struct {
+	cli *whatsmeow.Client
+}
+
+// NewMessenger wraps a whatsmeow client as a Messenger
+func NewMessenger(cli *whatsmeow.Client) Messenger {
+	return &waMessenger{cli: cli}
+}
+
+func (w *waMessenger) SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+	return w.cli.SendMessage(ctx, to, message, extra...)
+}
+
+func (w *waMessenger) BuildReaction(chat, sender types.JID, id types.MessageID, emoji string) *waE2E.Message {
+	return w.cli.BuildReaction(chat, sender, id, emoji)
+}
+
+func (w *waMessenger) Upload(ctx context.Context, data []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	return w.cli.Upload(ctx, data, mediaType)
+}
+
+func (w *waMessenger) UploadReader(ctx context.Context, data io.Reader, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	return w.cli.UploadReader(ctx, data, nil, mediaType)
+}
+
+func (w *waMessenger) Download(ctx context.Context, media whatsmeow.DownloadableMessage) ([]byte, error) {
+	return w.cli.Download(ctx, media)
+}
+
+func (w *waMessenger) RequestMediaRetry(ctx context.Context, info types.MessageInfo, mediaKey []byte) (string, error) {
+	waiter := make(chan *events.MediaRetry, 1)
+	mediaRetryMutex.Lock()
+	mediaRetries[info.ID] = waiter
+	mediaRetryMutex.Unlock()
+
+	defer func() {
+		mediaRetryMutex.Lock()
+		delete(mediaRetries, info.ID)
+		mediaRetryMutex.Unlock()
+	}()
+
+	if err := w.cli.SendMediaRetryReceipt(&info, mediaKey); err != nil {
+		return "", err
+	}
+
+	select {
+	case evt := <-waiter:
+		notification, err := whatsmeow.DecryptMediaRetryNotification(evt, mediaKey)
+		if err != nil {
+			return "", err
+		}
+		if notification.GetResult() != waMmsRetry.MediaRetryNotification_SUCCESS {
+			return "", fmt.Errorf("media retry failed: %s", notification.GetResult())
+		}
+		return notification.GetDirectPath(), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (w *waMessenger) GetGroupInfo(jid types.JID) (*types.GroupInfo, error) {
+	return w.cli.GetGroupInfo(jid)
+}
+
+func (w *waMessenger) MarkRead(ids []types.MessageID, timestamp time.Time, chat, sender types.JID) error {
+	return w.cli.MarkRead(ids, timestamp, chat, sender)
+}
+
+func (w *waMessenger) SetStatusMessage(status string) error {
+	return w.cli.SetStatusMessage(status)
+}
+
+func (w *waMessenger) OwnID() types.JID {
+	if w.cli.Store == nil || w.cli.Store.ID == nil {
+		return types.EmptyJID
+	}
+	return *w.cli.Store.ID
+}
+
+func (w *waMessenger) OwnLID() types.JID {
+	if w.cli.Store == nil {
+		return types.EmptyJID
+	}
+	return w.cli.Store.GetLID()
+}
+
+func (w *waMessenger) GetPNForLID(ctx context.Context, lid types.JID) (types.JID, error) {
+	if w.cli.Store == nil || w.cli.Store.LIDs == nil {
+		return types.EmptyJID, nil
+	}
+	return w.cli.Store.LIDs.GetPNForLID(ctx, lid)
+}
+
+func (w *waMessenger) GetLIDForPN(ctx context.Context, pn types.JID) (types.JID, error) {
+	if w.cli.Store == nil || w.cli.Store.LIDs == nil {
+		return types.EmptyJID, nil
+	}
+	return w.cli.Store.LIDs.GetLIDForPN(ctx, pn)
+}
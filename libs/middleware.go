@@ -0,0 +1,68 @@
+package libs
+
+import (
+	"sync"
+)
+
+// NextFunc passes control to the next middleware in the chain
+type NextFunc func()
+
+// MiddlewareFunc is a single step of the message dispatch pipeline.
+// A middleware may stop processing by returning without calling next.
+type MiddlewareFunc func(conn *IClient, m *IMessage, next NextFunc)
+
+// HandlerFunc is the final step of the pipeline, run after every middleware
+type HandlerFunc func(conn *IClient, m *IMessage)
+
+var (
+	middlewares      []MiddlewareFunc
+	middlewaresMutex sync.RWMutex
+)
+
+// Use registers a middleware at the end of the dispatch chain
+func Use(mw MiddlewareFunc) {
+	if mw == nil {
+		return
+	}
+
+	middlewaresMutex.Lock()
+	defer middlewaresMutex.Unlock()
+
+	middlewares = append(middlewares, mw)
+}
+
+// GetMiddlewares returns a snapshot of the registered middlewares
+func GetMiddlewares() []MiddlewareFunc {
+	middlewaresMutex.RLock()
+	defer middlewaresMutex.RUnlock()
+
+	chain := make([]MiddlewareFunc, len(middlewares))
+	copy(chain, middlewares)
+	return chain
+}
+
+// Dispatch runs a message through every registered middleware in order
+// and finally through the given handler
+func Dispatch(conn *IClient, m *IMessage, final HandlerFunc) {
+	if m == nil {
+		return
+	}
+
+	chain := GetMiddlewares()
+
+	var run func(i int)
+	run = func(i int) {
+		if i < len(chain) {
+			chain[i](conn, m, func() {
+				run(i + 1)
+			})
+			return
+		}
+
+		if final != nil {
+			final(conn, m)
+		}
+	}
+
+	run(0)
+}
@@ -0,0 +1,138 @@
+package libs
+
+import (
+	"fmt"
+	"zumygo/config"
+	"zumygo/helpers"
+)
+
+// Permission is the minimum role required to run a command
+type Permission int
+
+const (
+	PermEveryone Permission = iota
+	PermRegistered
+	PermPremium
+	PermGroupAdmin
+	PermMod
+	PermOwner
+)
+
+// String returns the role name used in denial messages and menus
+func (p Permission) String() string {
+	switch p {
+	case PermRegistered:
+		return "registered"
+	case PermPremium:
+		return "premium"
+	case PermGroupAdmin:
+		return "admin"
+	case PermMod:
+		return "mod"
+	case PermOwner:
+		return "owner"
+	default:
+		return "everyone"
+	}
+}
+
+// Denial message catalog keys shared by every permission check
+const (
+	DenyOwner      = "perm.owner"
+	DenyMod        = "perm.mod"
+	DenyPremium    = "perm.premium"
+	DenyGroupAdmin = "perm.admin"
+	DenyRegistered = "perm.registered"
+	DenyBotAdmin   = "perm.bot_admin"
+	DenyGroupInfo  = "perm.group_info"
+)
+
+// IsMod reports whether the sender is a bot moderator or the owner
+func (m *IMessage) IsMod() bool {
+	if m.IsOwner {
+		return true
+	}
+	return config.Config != nil && config.Config.IsMod(m.Sender.ToNonAD().String())
+}
+
+// IsPremium reports whether the sender has premium access
+func (m *IMessage) IsPremium() bool {
+	if m.IsMod() {
+		return true
+	}
+	if config.Config != nil && config.Config.IsPrem(m.Sender.ToNonAD().String()) {
+		return true
+	}
+	return m.User != nil && m.User.Premium
+}
+
+// IsRegistered reports whether the sender is registered or holds a higher role
+func (m *IMessage) IsRegistered() bool {
+	return m.IsPremium() || (m.User != nil && m.User.Registered)
+}
+
+// CheckPermission verifies the command role and bot admin requirements and
+// returns the denial message key when the sender may not run the command
+func CheckPermission(conn *IClient, cmd *ICommand, m *IMessage) (string, bool) {
+	if denial, ok := checkRole(conn, cmd.Permission, m); !ok {
+		return denial, false
+	}
+
+	if cmd.BotAdmin && m.Info.IsGroup {
+		admin, err := conn.IsBotGroupAdmin(m.Info.Chat)
+		if err != nil {
+			helpers.Logger{}.Error(fmt.Sprintf("Failed to check bot admin: %v", err))
+			return DenyGroupInfo, false
+		}
+		if !admin {
+			return DenyBotAdmin, false
+		}
+	}
+
+	return "", true
+}
+
+// CanUse reports whether the sender holds the role the command requires,
+// regardless of whether the bot could run it in the chat
+func CanUse(conn *IClient, cmd *ICommand, m *IMessage) bool {
+	_, ok := checkRole(conn, cmd.Permission, m)
+	return ok
+}
+
+// checkRole verifies the sender holds the given role
+func checkRole(conn *IClient, permission Permission, m *IMessage) (string, bool) {
+	switch permission {
+	case PermOwner:
+		if !m.IsOwner {
+			return DenyOwner, false
+		}
+	case PermMod:
+		if !m.IsMod() {
+			return DenyMod, false
+		}
+	case PermGroupAdmin:
+		if !m.IsMod() {
+			if !m.Info.IsGroup {
+				return DenyGroupAdmin, false
+			}
+			admin, err := conn.IsGroupAdmin(m.Info.Chat, m.Sender)
+			if err != nil {
+				helpers.Logger{}.Error(fmt.Sprintf("Failed to check group admin: %v", err))
+				return DenyGroupInfo, false
+			}
+			if !admin {
+				return DenyGroupAdmin, false
+			}
+		}
+	case PermPremium:
+		if !m.IsPremium() {
+			return DenyPremium, false
+		}
+	case PermRegistered:
+		if !m.IsRegistered() {
+			return DenyRegistered, false
+		}
+	}
+
+	return "", true
+}
@@ -0,0 +1,157 @@
+package libs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// regexMeta lists the characters that mark a command name as a regex pattern
+const regexMeta = "|*+?()[]{}.^$\\"
+
+// commandRouter resolves command names in constant time for literal names
+// and aliases, falling back to a single compiled alternation for patterns
+type commandRouter struct {
+	mutex    sync.RWMutex
+	exact    map[string]*ICommand
+	patterns []string
+	targets  []*ICommand
+	groups   []int
+	compiled *regexp.Regexp
+	dirty    bool
+}
+
+var router = newRouter()
+
+// newRouter creates an empty command router
+func newRouter() *commandRouter {
+	return &commandRouter{
+		exact: make(map[string]*ICommand),
+	}
+}
+
+// add indexes a command by its name and aliases
+func (r *commandRouter) add(cmd *ICommand) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if literals, ok := expandLiterals(cmd.Name); ok {
+		for _, name := range literals {
+			r.index(name, cmd)
+		}
+	} else {
+		// Validate the pattern on its own so one bad command cannot break the router
+		if _, err := regexp.Compile(`^(?:` + cmd.Name + `)$`); err != nil {
+			fmt.Printf("Skipping invalid command pattern %q: %v\n", cmd.Name, err)
+		} else {
+			r.patterns = append(r.patterns, cmd.Name)
+			r.targets = append(r.targets, cmd)
+			r.dirty = true
+		}
+	}
+
+	for _, alias := range cmd.As {
+		r.index(alias, cmd)
+	}
+}
+
+// index maps a literal name to a command, keeping the first registration
+func (r *commandRouter) index(name string, cmd *ICommand) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return
+	}
+	if _, exists := r.exact[name]; !exists {
+		r.exact[name] = cmd
+	}
+}
+
+// compile rebuilds the pattern alternation, one capture group per command
+func (r *commandRouter) compile() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.dirty {
+		return
+	}
+
+	// Inner groups shift the numbering, so remember where each command starts
+	alternatives := make([]string, len(r.patterns))
+	r.groups = make([]int, len(r.patterns))
+	group := 1
+	for i, pattern := range r.patterns {
+		alternatives[i] = `(` + pattern + `)`
+		r.groups[i] = group
+		group += 1 + regexp.MustCompile(pattern).NumSubexp()
+	}
+
+	r.compiled = regexp.MustCompile(`^(?:` + strings.Join(alternatives, "|") + `)$`)
+	r.dirty = false
+}
+
+// resolve finds the command registered for a name
+func (r *commandRouter) resolve(name string) (*ICommand, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	r.mutex.RLock()
+	cmd, ok := r.exact[name]
+	dirty := r.dirty
+	r.mutex.RUnlock()
+
+	if ok {
+		return cmd, true
+	}
+
+	if dirty {
+		r.compile()
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.compiled == nil {
+		return nil, false
+	}
+
+	match := r.compiled.FindStringSubmatchIndex(name)
+	if match == nil {
+		return nil, false
+	}
+
+	for i, group := range r.groups {
+		if match[2*group] >= 0 {
+			return r.targets[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// expandLiterals splits names like "ping" or "(play|p|song)" into plain
+// literals; it reports false when the name needs real regex matching
+func expandLiterals(name string) ([]string, bool) {
+	if !strings.ContainsAny(name, regexMeta) {
+		return []string{name}, true
+	}
+
+	inner := name
+	if strings.HasPrefix(inner, "(") && strings.HasSuffix(inner, ")") {
+		inner = inner[1 : len(inner)-1]
+	}
+
+	parts := strings.Split(inner, "|")
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, regexMeta) {
+			return nil, false
+		}
+	}
+	return parts, true
+}
+
+// Resolve returns the command registered under a name or alias
+func Resolve(name string) (*ICommand, bool) {
+	return router.resolve(strings.ToLower(name))
+}
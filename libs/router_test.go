@@ -0,0 +1,72 @@
+package libs
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRouterResolve(t *testing.T) {
+	r := newRouter()
+	r.add(&ICommand{Name: "ping"})
+	r.add(&ICommand{Name: "(play|p|song)", As: []string{"music"}})
+	r.add(&ICommand{Name: "(x|y)+"})
+	r.add(&ICommand{Name: "img([0-9]+)?"})
+
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"ping", "ping"},
+		{"p", "(play|p|song)"},
+		{"song", "(play|p|song)"},
+		{"music", "(play|p|song)"},
+		{"xyx", "(x|y)+"},
+		{"img", "img([0-9]+)?"},
+		{"img42", "img([0-9]+)?"},
+	}
+
+	for _, c := range cases {
+		cmd, ok := r.resolve(c.name)
+		if !ok {
+			t.Fatalf("resolve(%q): not found", c.name)
+		}
+		if cmd.Name != c.want {
+			t.Errorf("resolve(%q) = %q, want %q", c.name, cmd.Name, c.want)
+		}
+	}
+
+	for _, name := range []string{"", "pin", "plays", "xz", "imgx"} {
+		if cmd, ok := r.resolve(name); ok {
+			t.Errorf("resolve(%q) = %q, want no match", name, cmd.Name)
+		}
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, size := range []int{10, 100, 1000} {
+		r := newRouter()
+		for i := 0; i < size; i++ {
+			r.add(&ICommand{
+				Name: fmt.Sprintf("(cmd%d|c%d)", i, i),
+				As:   []string{fmt.Sprintf("alias%d", i)},
+			})
+		}
+		last := fmt.Sprintf("alias%d", size-1)
+
+		b.Run(fmt.Sprintf("alias/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, ok := r.resolve(last); !ok {
+					b.Fatal("command not found")
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("miss/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, ok := r.resolve("unknown"); ok {
+					b.Fatal("unexpected match")
+				}
+			}
+		})
+	}
+}
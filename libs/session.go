@@ -0,0 +1,146 @@
+package libs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSessionTimeout applies when Await is called without a timeout
+const DefaultSessionTimeout = 60 * time.Second
+
+var (
+	// ErrSessionTimeout is returned when the user does not answer in time
+	ErrSessionTimeout = errors.New("session timed out")
+	// ErrSessionCancelled is returned when the user sends a cancel keyword or
+	// a newer session replaces the pending one
+	ErrSessionCancelled = errors.New("session cancelled")
+)
+
+// SessionCancelWords end a pending session instead of answering it
+var SessionCancelWords = []string{"cancel", "batal", "stop"}
+
+// sessionWaiter receives the next message of one user in one chat
+type sessionWaiter struct {
+	ch chan *IMessage
+}
+
+var (
+	sessions     = make(map[string]*sessionWaiter)
+	sessionMutex sync.Mutex
+)
+
+// sessionKey identifies the user and chat a session listens to
+func sessionKey(m *IMessage) string {
+	return m.Info.Chat.String() + "|" + m.Sender.ToNonAD().String()
+}
+
+// Await waits for the next message from the same user in the same chat as m.
+// It fails on timeout, on cancellation of ctx, or when the user answers with
+// one of SessionCancelWords.
+func Await(ctx context.Context, m *IMessage, timeout time.Duration) (*IMessage, error) {
+	if timeout <= 0 {
+		timeout = DefaultSessionTimeout
+	}
+
+	key := sessionKey(m)
+	waiter := &sessionWaiter{ch: make(chan *IMessage, 1)}
+
+	sessionMutex.Lock()
+	if previous, exists := sessions[key]; exists {
+		previous.ch <- nil
+	}
+	sessions[key] = waiter
+	sessionMutex.Unlock()
+
+	defer func() {
+		sessionMutex.Lock()
+		if sessions[key] == waiter {
+			delete(sessions, key)
+		}
+		sessionMutex.Unlock()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case reply := <-waiter.ch:
+		if reply == nil || isCancelWord(reply) {
+			return nil, ErrSessionCancelled
+		}
+		return reply, nil
+	case <-timer.C:
+		return nil, ErrSessionTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Ask sends a prompt to the user and awaits the answer
+func Ask(ctx context.Context, m *IMessage, prompt string, timeout time.Duration) (*IMessage, error) {
+	if _, err := m.Reply(prompt); err != nil {
+		return nil, err
+	}
+	return Await(ctx, m, timeout)
+}
+
+// Confirm asks a yes/no question and reports whether the user agreed
+func Confirm(ctx context.Context, m *IMessage, prompt string, timeout time.Duration) (bool, error) {
+	reply, err := Ask(ctx, m, prompt+"\n\n"+m.T("session.confirm"), timeout)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(reply.Body)) {
+	case "yes", "y", "ya", "iya", "yup":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// HasSession reports whether a session is waiting for this message's sender
+func HasSession(m *IMessage) bool {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	_, exists := sessions[sessionKey(m)]
+	return exists
+}
+
+// DeliverSession hands the message to a pending session and reports whether
+// it was consumed
+func DeliverSession(m *IMessage) bool {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	key := sessionKey(m)
+	waiter, exists := sessions[key]
+	if !exists {
+		return false
+	}
+
+	delete(sessions, key)
+	waiter.ch <- m
+	return true
+}
+
+// isCancelWord reports whether the message asks to end the session, with or
+// without a command prefix
+func isCancelWord(m *IMessage) bool {
+	body := strings.TrimSpace(m.Body)
+	if prefix, ok := ExtractPrefix(m.Info.Chat.String(), body); ok {
+		body = strings.TrimPrefix(body, prefix)
+	}
+	body = strings.ToLower(body)
+
+	for _, word := range SessionCancelWords {
+		if body == word {
+			return true
+		}
+	}
+	return false
+}
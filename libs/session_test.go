@@ -0,0 +1,143 @@
+package libs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"zumygo/libs"
+	"zumygo/testkit"
+)
+
+// sessionResult is what a pending Await or Ask returned
+type sessionResult struct {
+	reply *libs.IMessage
+	err   error
+}
+
+// awaitAsync starts Await for m and waits until the session is registered
+func awaitAsync(t *testing.T, m *libs.IMessage, timeout time.Duration) <-chan sessionResult {
+	t.Helper()
+
+	done := make(chan sessionResult, 1)
+	go func() {
+		reply, err := libs.Await(context.Background(), m, timeout)
+		done <- sessionResult{reply, err}
+	}()
+	waitForSession(t, m)
+	return done
+}
+
+// waitForSession blocks until a session is pending for the sender of m
+func waitForSession(t *testing.T, m *libs.IMessage) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !libs.HasSession(m) {
+		if time.Now().After(deadline) {
+			t.Fatalf("no session registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAskReceivesAnswer(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	done := make(chan sessionResult, 1)
+	go func() {
+		reply, err := libs.Ask(context.Background(), m, "your name?", time.Second)
+		done <- sessionResult{reply, err}
+	}()
+	waitForSession(t, m)
+
+	// Other senders in the same chat do not answer the session
+	h.Private(h.Owner, "not me")
+	if !libs.HasSession(m) {
+		t.Fatalf("another sender's message consumed the session")
+	}
+
+	h.Private(h.User, "Budi")
+	result := <-done
+	if result.err != nil || result.reply.Body != "Budi" {
+		t.Fatalf("Ask = %v, %v; want Budi", result.reply, result.err)
+	}
+	if h.Replies()[0] != "your name?" {
+		t.Errorf("prompt = %q, want the question", h.Replies()[0])
+	}
+	if libs.HasSession(m) {
+		t.Errorf("session still pending after the answer")
+	}
+}
+
+func TestAwaitTimeout(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	if _, err := libs.Await(context.Background(), m, 20*time.Millisecond); !errors.Is(err, libs.ErrSessionTimeout) {
+		t.Errorf("err = %v, want ErrSessionTimeout", err)
+	}
+	if libs.HasSession(m) {
+		t.Errorf("session still pending after the timeout")
+	}
+}
+
+func TestAwaitCancelWords(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	for _, word := range []string{"batal", "CANCEL", ".stop"} {
+		done := awaitAsync(t, m, time.Second)
+		h.Private(h.User, word)
+
+		if result := <-done; !errors.Is(result.err, libs.ErrSessionCancelled) {
+			t.Errorf("%q: err = %v, want ErrSessionCancelled", word, result.err)
+		}
+	}
+}
+
+func TestNewSessionReplacesPending(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	first := awaitAsync(t, m, time.Second)
+	second := make(chan sessionResult, 1)
+	go func() {
+		reply, err := libs.Await(context.Background(), m, time.Second)
+		second <- sessionResult{reply, err}
+	}()
+
+	if result := <-first; !errors.Is(result.err, libs.ErrSessionCancelled) {
+		t.Fatalf("first session err = %v, want ErrSessionCancelled", result.err)
+	}
+	waitForSession(t, m)
+
+	h.Private(h.User, "answer")
+	if result := <-second; result.err != nil || result.reply.Body != "answer" {
+		t.Errorf("second session = %v, %v; want the answer", result.reply, result.err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	h := testkit.New(t)
+	m := h.Private(h.User, "hi")
+
+	cases := map[string]bool{"ya": true, "Yes": true, "no": false, "later": false}
+	for answer, want := range cases {
+		done := make(chan bool, 1)
+		go func() {
+			ok, err := libs.Confirm(context.Background(), m, "sure?", time.Second)
+			if err != nil {
+				t.Errorf("%q: Confirm: %v", answer, err)
+			}
+			done <- ok
+		}()
+		waitForSession(t, m)
+		h.Private(h.User, answer)
+
+		if got := <-done; got != want {
+			t.Errorf("Confirm(%q) = %v, want %v", answer, got, want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package libs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// MediaLimits are the largest sizes WhatsApp accepts for each kind of media,
+// in bytes. Larger images, videos and audio are sent as documents instead.
+var MediaLimits = map[string]int64{
+	MediaImage:    16 << 20,
+	MediaVideo:    64 << 20,
+	MediaAudio:    16 << 20,
+	MediaVoice:    16 << 20,
+	MediaSticker:  1 << 20,
+	MediaDocument: 2 << 30,
+}
+
+// ErrUploadTooLarge is returned for media over the WhatsApp document limit
+var ErrUploadTooLarge = errors.New("media exceeds the WhatsApp size limit")
+
+// httpClient fetches remote media. Only the wait for response headers is
+// bounded, so large bodies are limited by the request context instead.
+var httpClient = func() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport}
+}()
+
+// ITempFile is media saved to a temporary file
+type ITempFile struct {
+	Path     string
+	Size     int64
+	Mimetype string
+}
+
+// Cleanup removes the file; leftovers are removed after TempMediaTTL anyway
+func (file *ITempFile) Cleanup() {
+	os.Remove(file.Path)
+}
+
+// FetchFile streams the body at url to a temporary file, refusing bodies
+// larger than MaxDownloadSize. Call Cleanup on the result when done.
+func (conn *IClient) FetchFile(ctx context.Context, url string) (*ITempFile, error) {
+	body, mimetype, err := fetch(ctx, url, MaxDownloadSize())
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	file, err := spoolTemp(body, MaxDownloadSize())
+	if err != nil {
+		return nil, err
+	}
+	if mimetype != "" && mimetype != "application/octet-stream" {
+		file.Mimetype = mimetype
+	}
+	return file, nil
+}
+
+// fetch starts a GET request and returns its body with the declared content
+// type, failing early when the declared length is over limit
+func fetch(ctx context.Context, url string, limit int64) (io.ReadCloser, string, error) {
+	if url == "" {
+		return nil, "", fmt.Errorf("URL is required")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch URL: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("HTTP request failed with status: %d", resp.StatusCode)
+	}
+	if resp.ContentLength > limit {
+		resp.Body.Close()
+		return nil, "", ErrMediaTooLarge
+	}
+
+	mimetype := strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0])
+	return resp.Body, mimetype, nil
+}
+
+// readLimited reads all of r, failing once it goes over limit bytes
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrMediaTooLarge
+	}
+	return data, nil
+}
+
+// spoolTemp copies r to a temporary file of at most limit bytes and sniffs
+// its type
+func spoolTemp(r io.Reader, limit int64) (*ITempFile, error) {
+	file, err := os.CreateTemp(tempMediaDir(), "media-*")
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := io.Copy(file, io.LimitReader(r, limit+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > limit {
+		err = ErrMediaTooLarge
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	result := &ITempFile{Path: file.Name(), Size: size}
+	result.Mimetype, err = sniffFile(result.Path)
+	if err != nil {
+		result.Cleanup()
+		return nil, err
+	}
+	return result, nil
+}
+
+// sniffFile detects the type of a file from its first bytes
+func sniffFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// fitMedia returns the kind media of the given size can be sent as: the
+// requested kind within its limit, else a document within the document limit
+func fitMedia(kind string, size int64) (string, error) {
+	if limit, ok := MediaLimits[kind]; !ok || size <= limit {
+		return kind, nil
+	}
+	if size <= MediaLimits[MediaDocument] {
+		return MediaDocument, nil
+	}
+	return "", ErrUploadTooLarge
+}
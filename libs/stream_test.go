@@ -0,0 +1,119 @@
+package libs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"zumygo/config"
+	"zumygo/libs"
+	"zumygo/testkit"
+)
+
+func TestFetchFileStreamsWithinLimit(t *testing.T) {
+	h := testkit.New(t)
+	config.Config.MaxDownloadSize = 1
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			w.Header().Set("Content-Type", "video/mp4")
+			w.Write([]byte("small video"))
+		case "/declared":
+			w.Header().Set("Content-Length", "2097152")
+			w.Write(make([]byte, 2<<20))
+		case "/chunked":
+			w.(http.Flusher).Flush()
+			w.Write(make([]byte, 2<<20))
+		}
+	}))
+	defer server.Close()
+
+	file, err := h.Client.FetchFile(context.Background(), server.URL+"/small")
+	if err != nil {
+		t.Fatalf("FetchFile: %v", err)
+	}
+	data, _ := os.ReadFile(file.Path)
+	if string(data) != "small video" || file.Size != 11 || file.Mimetype != "video/mp4" {
+		t.Errorf("file = %q size %d type %q", data, file.Size, file.Mimetype)
+	}
+	file.Cleanup()
+	if _, err := os.Stat(file.Path); !os.IsNotExist(err) {
+		t.Errorf("file kept after Cleanup")
+	}
+
+	for _, path := range []string{"/declared", "/chunked"} {
+		if _, err := h.Client.FetchFile(context.Background(), server.URL+path); !errors.Is(err, libs.ErrMediaTooLarge) {
+			t.Errorf("FetchFile %s: err = %v, want ErrMediaTooLarge", path, err)
+		}
+		if _, err := h.Client.GetBytes(context.Background(), server.URL+path); !errors.Is(err, libs.ErrMediaTooLarge) {
+			t.Errorf("GetBytes %s: err = %v, want ErrMediaTooLarge", path, err)
+		}
+	}
+}
+
+func TestBuilderFallsBackToDocument(t *testing.T) {
+	h := testkit.New(t)
+
+	limit := libs.MediaLimits[libs.MediaImage]
+	libs.MediaLimits[libs.MediaImage] = 16
+	defer func() { libs.MediaLimits[libs.MediaImage] = limit }()
+
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 32)
+	_, err := h.Client.Message(h.User).Reader(libs.MediaImage, strings.NewReader(png)).Text("big").Send(context.Background())
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	document := h.Fake.Sent()[0].Message.GetDocumentMessage()
+	if document == nil {
+		t.Fatalf("sent %v, want a document", h.Fake.Sent()[0].Message)
+	}
+	if document.GetFileName() != "image.png" || document.GetMimetype() != "image/png" || document.GetCaption() != "big" {
+		t.Errorf("document = %q %q %q", document.GetFileName(), document.GetMimetype(), document.GetCaption())
+	}
+	if document.GetFileLength() != uint64(len(png)) {
+		t.Errorf("FileLength = %d, want %d", document.GetFileLength(), len(png))
+	}
+
+	// Within the limit the image stays inline, streamed from its file
+	path := t.TempDir() + "/small.png"
+	os.WriteFile(path, []byte(png[:12]), 0644)
+	h.Client.Message(h.User).File(libs.MediaImage, path).Send(context.Background())
+	image := h.Fake.Sent()[1].Message.GetImageMessage()
+	if image == nil {
+		t.Fatalf("sent %v, want an image", h.Fake.Sent()[1].Message)
+	}
+	if data, _ := h.Client.Messenger.Download(context.Background(), image); !bytes.Equal(data, []byte(png[:12])) {
+		t.Errorf("uploaded %q, want the file content", data)
+	}
+}
+
+func TestBuilderHidesTempFileNames(t *testing.T) {
+	h := testkit.New(t)
+
+	media := &libs.IMedia{Data: []byte("%PDF-1.4 report"), Ext: ".pdf"}
+	temp, cleanup, err := media.SaveTemp()
+	if err != nil {
+		t.Fatalf("SaveTemp: %v", err)
+	}
+	defer cleanup()
+
+	named := t.TempDir() + "/report.pdf"
+	os.WriteFile(named, media.Data, 0644)
+
+	h.Client.Message(h.User).File(libs.MediaDocument, temp).Send(context.Background())
+	h.Client.Message(h.User).File(libs.MediaDocument, named).Send(context.Background())
+
+	sent := h.Fake.Sent()
+	if name := sent[0].Message.GetDocumentMessage().GetFileName(); name != "document.pdf" {
+		t.Errorf("temp file sent as %q, want document.pdf", name)
+	}
+	if name := sent[1].Message.GetDocumentMessage().GetFileName(); name != "report.pdf" {
+		t.Errorf("named file sent as %q, want report.pdf", name)
+	}
+}
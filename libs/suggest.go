@@ -0,0 +1,74 @@
+package libs
+
+import (
+	"strings"
+)
+
+// minSuggestLength keeps very short typos from matching unrelated commands
+const minSuggestLength = 3
+
+// Suggest returns the registered name or alias closest to an unknown command,
+// skipping commands disabled in the chat and those allowed rejects
+func Suggest(name, chatID string, allowed func(*ICommand) bool) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len([]rune(name)) < minSuggestLength {
+		return "", false
+	}
+
+	maxDistance := 1
+	if len([]rune(name)) > 4 {
+		maxDistance = 2
+	}
+
+	router.mutex.RLock()
+	candidates := make(map[string]*ICommand, len(router.exact))
+	for literal, cmd := range router.exact {
+		candidates[literal] = cmd
+	}
+	router.mutex.RUnlock()
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for literal, cmd := range candidates {
+		if literal == name || cmd.Execute == nil || IsCommandDisabled(cmd, chatID) {
+			continue
+		}
+		if allowed != nil && !allowed(cmd) {
+			continue
+		}
+
+		distance := editDistance(name, literal)
+		if distance < bestDistance || (distance == bestDistance && literal < best) {
+			best, bestDistance = literal, distance
+		}
+	}
+
+	if best == "" {
+		return "", false
+	}
+	return best, true
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,88 @@
+package libs
+
+import (
+	"strings"
+	"zumygo/database"
+)
+
+// tagKeyPrefix marks a disabled entry that targets a whole tag
+const tagKeyPrefix = "tag:"
+
+// CommandKey returns the stable name a command is stored under when it is
+// disabled: its first alias, or its pattern when it has none
+func CommandKey(cmd *ICommand) string {
+	if len(cmd.As) > 0 {
+		return strings.ToLower(cmd.As[0])
+	}
+	return strings.ToLower(cmd.Name)
+}
+
+// TagKey returns the stored name of a disabled tag
+func TagKey(tag string) string {
+	return tagKeyPrefix + strings.ToLower(tag)
+}
+
+// ResolveToggleTarget maps user input to a command or tag key, preferring a
+// command when both match
+func ResolveToggleTarget(input string) (string, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return "", false
+	}
+
+	if cmd, ok := Resolve(input); ok {
+		return CommandKey(cmd), true
+	}
+
+	tag := strings.TrimPrefix(input, tagKeyPrefix)
+	for _, cmd := range lists {
+		if strings.ToLower(cmd.Tags) == tag {
+			return TagKey(tag), true
+		}
+	}
+	return "", false
+}
+
+// IsDisabledGlobally reports whether a toggle target key is disabled in
+// every chat, directly or, for a command, through its tag
+func IsDisabledGlobally(key string) bool {
+	if database.DB == nil {
+		return false
+	}
+	if !strings.HasPrefix(key, tagKeyPrefix) {
+		if cmd, ok := Resolve(key); ok {
+			return IsCommandDisabled(cmd, "")
+		}
+	}
+	for _, disabled := range database.DB.GetDisabledCommands("") {
+		if disabled == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCommandDisabled reports whether a command is disabled globally or in the
+// given chat, either by name or through its tag; NoDisable commands never are
+func IsCommandDisabled(cmd *ICommand, chatID string) bool {
+	if cmd == nil || cmd.NoDisable || database.DB == nil {
+		return false
+	}
+
+	keys := database.DB.GetDisabledCommands("")
+	if chatID != "" {
+		keys = append(keys, database.DB.GetDisabledCommands(chatID)...)
+	}
+	if len(keys) == 0 {
+		return false
+	}
+
+	name := CommandKey(cmd)
+	tag := TagKey(cmd.Tags)
+	for _, key := range keys {
+		if key == name || (cmd.Tags != "" && key == tag) {
+			return true
+		}
+	}
+	return false
+}
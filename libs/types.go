@@ -1,13 +1,19 @@
 package libs
 
 import (
+	"context"
+	"time"
+	"zumygo/database"
+
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 )
 
+// IClient is the connection handed to commands. All traffic goes through
+// Messenger, so commands run the same against WhatsApp and in tests.
 type IClient struct {
-	WA *whatsmeow.Client
+	Messenger Messenger
 }
 
 type ICommand struct {
@@ -16,14 +22,19 @@ type ICommand struct {
 	Description string
 	Tags        string
 	IsPrefix    bool
-	IsOwner     bool
+	Permission  Permission
+	BotAdmin    bool
+	NoDisable   bool
 	IsMedia     bool
 	IsQuery     bool
 	IsGroup     bool
 	IsWait      bool
+	IsSession   bool // Waits for the user's answers, so it runs off the worker pool
 	IsPrivate   bool
-	Before      func(conn *IClient, m *IMessage)
-	Execute     func(conn *IClient, m *IMessage) bool
+	Args        []IArg
+	Cooldown    ICooldown
+	Timeout     time.Duration
+	Execute     func(ctx context.Context, conn *IClient, m *IMessage) bool
 }
 
 type IMessage struct {
@@ -34,12 +45,33 @@ type IMessage struct {
 	Text       string
 	Args       []string
 	Command    string
+	Prefix     string
+	Cmd        *ICommand
+	Params     *IParams
+	User       *database.User
+	ChatData   *database.Chat
 	Message    *waE2E.Message
+	Kind       string // helpers.Kind* value, such as "image" or "poll"
+	IsEdited   bool
+	IsViewOnce bool
 	Media      whatsmeow.DownloadableMessage
 	IsMedia    string
 	Expiration uint32
 	Quoted     *waE2E.ContextInfo
-	Client     *IClient
-	Reply      func(text string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
-	React      func(emoji string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
+
+	// Typed context: mentioned users, the quoted message, and for groups
+	// the subject and admin status of the sender and the bot, filled by
+	// LoadGroupContext once the message resolves to a command
+	Mentions     []types.JID
+	QuotedSender types.JID
+	QuotedText   string
+	QuotedMedia  whatsmeow.DownloadableMessage
+	PushName     string
+	IsGroupAdmin bool
+	IsBotAdmin   bool
+	GroupName    string
+
+	Client *IClient
+	Reply  func(text string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
+	React  func(emoji string, opts ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error)
 }
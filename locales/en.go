@@ -0,0 +1,241 @@
+package locales
+
+func init() {
+	Register(EN, map[string]string{
+		// Common
+		"common.wait":           "_*Please wait, processing...*_",
+		"common.error":          "_*Server Error*_",
+		"common.correct":        "Correct ✅\n",
+		"common.wrong":          "Wrong ❌\n",
+		"common.unknown":        "Unknown",
+		"common.db_unavailable": "❎ Database not available",
+		"common.no_client":      "❎ Client not available for sending media",
+		"common.cancelled":      "🚫 Cancelled",
+		"common.no_answer":      "⏰ No answer received, please start again",
+		"sticker.wait":          "*⫹⫺ Creating sticker...*",
+
+		// Dispatcher
+		"cmd.exec_error":     "An error occurred while executing the command",
+		"cmd.query_required": "Query Required",
+		"cmd.group_only":     "Commands only work in Group Chat",
+		"cmd.private_only":   "Commands only work in Private Chat",
+		"cmd.media_required": "Reply to Media Message, or send Media with Command",
+		"cmd.cooldown":       "⏳ Please wait %d seconds before using %s%s again",
+		"cmd.busy":           "⏳ The bot is busy right now, please try again in a moment",
+		"cmd.suggest":        "❓ Did you mean *%s%s*?",
+
+		// Permissions
+		"perm.owner":      "❎ This command is only for the bot owner",
+		"perm.mod":        "❎ This command is only for bot moderators",
+		"perm.premium":    "❎ This command is only for premium users",
+		"perm.admin":      "❎ This command is only for group admins",
+		"perm.registered": "❎ You must be registered to use this command",
+		"perm.bot_admin":  "❎ The bot must be a group admin to use this command",
+		"perm.group_info": "❎ Failed to fetch group admins, please try again",
+
+		// Arguments
+		"args.usage":    "*Usage:*",
+		"args.missing":  "Missing %s",
+		"args.number":   "%s must be a number",
+		"args.duration": "%s must be a duration like 30s, 5m, 1h or 1d",
+		"args.url":      "%s must be a valid URL",
+		"args.jid":      "%s must be a mention or phone number",
+		"args.enum":     "%s must be one of: %s",
+
+		// Sessions
+		"session.confirm": "Reply *yes* or *no*",
+
+		// Main commands
+		"desc.menu":         "Show bot menu with professional formatting",
+		"desc.help":         "Show detailed help and usage guide",
+		"desc.cancel":       "Cancel your running commands in this chat",
+		"desc.register":     "Register your name and age step by step",
+		"desc.language":     "Change the bot language for you or this chat",
+		"arg.language":      "Language code",
+		"arg.language.chat": "Apply to the whole chat (group admins)",
+		"cancel.none":       "❎ You have no running commands in this chat",
+		"cancel.done":       "✅ Cancelled %d running command(s)",
+		"ping.result":       "*Ping :* %.2f Seconds\n",
+		"language.current":  "🌐 *Language*\n\n*You:* %s\n*This chat:* %s\n*Available:* %s\n\n%s",
+		"language.unset":    "not set",
+		"language.invalid":  "❎ Unsupported language '%s'. Available: %s",
+		"language.user":     "✅ Your language is now *%s*",
+		"language.chat":     "✅ This chat's language is now *%s*",
+
+		"register.already":      "ℹ️ You are already registered as *%s*",
+		"register.ask_name":     "📝 What is your name?\n\n_Type *cancel* to stop_",
+		"register.name_invalid": "❎ Name must be between 1 and 32 characters",
+		"register.ask_age":      "🎂 How old are you?",
+		"register.age_invalid":  "❎ Age must be a number between 5 and 100",
+		"register.confirm":      "Register as *%s*, %d years old?",
+		"register.cancelled":    "🚫 Registration cancelled",
+		"register.done":         "✅ Registered as *%s*, %d years old",
+
+		"perf.report": "📊 Performance Report\n" +
+			"═══════════════════════\n\n" +
+			"⏱️  Uptime: %s\n" +
+			"📨 Messages: %d (%s/min)\n" +
+			"⚡ Commands: %d (%s/min)\n" +
+			"❌ Errors: %d (%s/min)\n" +
+			"💾 DB Operations: %d (%s/min)\n" +
+			"🌐 HTTP Requests: %d (%s/min)\n" +
+			"🎯 Cache Hit Rate: %s\n" +
+			"🧠 Memory Usage: %s\n" +
+			"🔄 Goroutines: %d\n" +
+			"🗑️  GC Count: %d\n" +
+			"📥 Queue: %d waiting, %d dropped (avg wait %s, max %s)\n",
+
+		// Menu
+		"menu.divider":           "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n",
+		"menu.title":             "🎯 *ZUMYGO BOT MENU*\n",
+		"menu.main_title":        "🎯 *ZUMYGO BOT - MAIN MENU*\n",
+		"menu.help_title":        "📚 *ZUMYGO BOT - HELP & GUIDE*\n",
+		"menu.list_title":        "🎯 *ZUMYGO BOT - COMPLETE COMMAND LIST*\n",
+		"menu.user":              "👤 User: %s\n",
+		"menu.welcome":           "👤 Welcome, %s!\n",
+		"menu.tagline":           "🤖 Your AI-powered WhatsApp assistant\n",
+		"menu.category":          "📱 Category: %s\n",
+		"menu.no_commands":       "❌ No commands found for this category.\n💡 Try: .menu to see all categories\n",
+		"menu.no_description":    "No description available",
+		"menu.categories":        "*📋 Available Categories:*\n\n",
+		"menu.category_count":    "   └ %d commands available\n\n",
+		"menu.navigation":        "💡 *Navigation:*\n• .menu - Show main menu\n• .menu [category] - Show specific category\n• .help - Show detailed help\n• Example: .menu downloader\n\n",
+		"menu.how_to":            "💡 *How to use:*\n• Reply with a number to open a category\n• .menu [category] - Explore specific category\n• .help - Show detailed help\n• Example: .menu downloader\n• Example: .menu owner\n\n",
+		"menu.status":            "🔧 *Bot Status:* Online ✅\n📊 *Version:* 2.0 Professional\n🌟 *Features:* Interactive & Fast",
+		"menu.powered":           "🔧 Powered by ZUMYGO Bot",
+		"menu.quick_start":       "*🚀 Quick Start Guide:*\n\n1. *Main Menu:* .menu\n2. *Category Menu:* .menu [category]\n3. *Detailed Help:* .help\n\n",
+		"menu.popular":           "*🔥 Popular Commands:*\n\n📥 *Download Commands:*\n• .p [query] - Download YouTube MP3\n• .tt [url] - Download TikTok video\n• .yt [query] - Search YouTube\n\n🏠 *Main Commands:*\n• .menu - Show main menu\n• .ping - Check bot status\n• .perf - Show performance stats\n\n",
+		"menu.category_examples": "*📂 Category Examples:*\n\n",
+		"menu.more_help":         "💡 *Need more help?*\n• Contact the bot owner\n• Check .menu for all commands\n",
+		"menu.list_usage":        "💡 *Usage:* .menu [category]\n",
+		"menu.select":            "📋 Choose a category",
+		"menu.row_count":         "%d commands",
+
+		// Group commands
+		"desc.disable":        "Disable a command or tag in this chat or globally",
+		"desc.enable":         "Enable a previously disabled command or tag",
+		"desc.disabled":       "List commands disabled in this chat and globally",
+		"desc.suggest":        "Turn \"did you mean\" suggestions on or off in this chat",
+		"arg.toggle.target":   "Command name, alias or tag",
+		"arg.toggle.global":   "Apply to every chat (owner only)",
+		"arg.suggest.state":   "Whether to suggest commands for typos",
+		"toggle.not_found":    "❎ No command or tag named '%s'",
+		"toggle.locked":       "❎ %s cannot be disabled",
+		"toggle.already":      "ℹ️ %s is already %s %s",
+		"toggle.global_off":   "⚠️ %s is disabled globally by the owner, so it stays off here",
+		"toggle.done":         "✅ %s %s %s",
+		"toggle.enabled":      "enabled",
+		"toggle.disabled":     "disabled",
+		"toggle.scope_chat":   "in this chat",
+		"toggle.scope_global": "globally",
+		"toggle.list_title":   "*🚫 DISABLED COMMANDS*\n\n",
+		"toggle.list_global":  "Global",
+		"toggle.list_chat":    "This chat",
+		"toggle.list_none":    "*%s:* none\n",
+		"suggest.on":          "✅ Command suggestions turned on in this chat",
+		"suggest.off":         "✅ Command suggestions turned off in this chat",
+		"desc.prefix":         "Show or change the command prefixes of this chat",
+		"arg.prefix.action":   "Set new prefixes, reset to the defaults or toggle no-prefix mode",
+		"arg.prefix.value":    "Prefixes separated by spaces",
+		"prefix.current":      "*🔣 PREFIX*\n\nPrefixes: %s\nNo-prefix mode: %s\nMentioning the bot always works as a prefix.\n\n%s",
+		"prefix.state_on":     "on",
+		"prefix.state_off":    "off",
+		"prefix.set":          "✅ Prefixes in this chat set to: %s",
+		"prefix.reset":        "✅ Prefixes in this chat reset to the defaults: %s",
+		"prefix.too_long":     "❎ Prefix '%s' is longer than %d characters",
+		"prefix.none_on":      "✅ Commands in this chat now work without a prefix",
+		"prefix.none_off":     "✅ Commands in this chat need a prefix again",
+
+		"desc.antidelete":      "Repost messages deleted in this chat (on by default in groups, off in private chats)",
+		"arg.antidelete.state": "Turn anti-delete on or off",
+		"antidelete.current":   "*🗑️ ANTI-DELETE*\n\nStatus: %s\n\n%s",
+		"antidelete.state_on":  "on",
+		"antidelete.state_off": "off",
+		"antidelete.on":        "✅ Deleted messages in this chat will be reposted",
+		"antidelete.off":       "✅ Deleted messages in this chat will no longer be reposted",
+		"antidelete.notice":    "🗑️ @%s deleted:",
+
+		// Owner commands
+		"desc.schedule":         "Schedule a message or command once",
+		"desc.cron":             "Schedule a repeating message or command",
+		"desc.jobs":             "List scheduled jobs",
+		"desc.unschedule":       "Cancel a scheduled job",
+		"arg.schedule.when":     "Delay such as 30m or 2h, a time HH:MM, or a date YYYY-MM-DD[THH:MM]",
+		"arg.schedule.to":       "Target chat JID or number; defaults to this chat",
+		"arg.schedule.cmd":      "Run the text as a command instead of sending it",
+		"arg.schedule.text":     "Message or command to send",
+		"arg.cron.expr":         "Five cron fields (minute hour day month weekday) or @hourly, @daily, @weekly, @monthly, followed by the text",
+		"arg.unschedule.id":     "Job ID from the jobs list",
+		"schedule.bad_time":     "❎ Cannot read '%s' as a delay, time or date",
+		"schedule.bad_cron":     "❎ Invalid cron expression: %s",
+		"schedule.bad_chat":     "❎ '%s' is not a valid chat",
+		"schedule.past":         "❎ That time is already in the past",
+		"schedule.never":        "❎ That cron expression never runs",
+		"schedule.done":         "✅ Job *%s* scheduled, next run %s in %s",
+		"schedule.none":         "ℹ️ No scheduled jobs",
+		"schedule.once":         "once",
+		"schedule.kind_message": "message",
+		"schedule.kind_command": "command",
+		"schedule.list_title":   "*⏰ SCHEDULED JOBS*\n\n",
+		"schedule.list_item":    "*%s* · %s · %s\nChat: %s\nNext: %s\n> %s\n\n",
+		"schedule.list_footer":  "Cancel with %sunschedule <id>",
+		"schedule.not_found":    "❎ No job with ID '%s'",
+		"schedule.cancelled":    "✅ Job *%s* cancelled",
+		"desc.autobio":          "Control auto update bio system",
+		"desc.mode":             "Switch between public and private mode",
+		"arg.autobio.action":    "Toggle, configure or force the bio update",
+		"arg.autobio.value":     "Template text or interval in minutes",
+		"arg.autobio.minutes":   "Interval in minutes",
+		"mode.private":          "The bot is now in private mode.",
+		"mode.public":           "The bot is now in public mode.",
+		"autobio.unavailable":   "❎ Bio system not available",
+		"autobio.enabled":       "✅ Enabled",
+		"autobio.disabled":      "❌ Disabled",
+		"autobio.running":       "✅ Running",
+		"autobio.stopped":       "❌ Stopped",
+		"autobio.status":        "*📝 Auto Bio System Status*\n\n*Status:* %s\n*Running:* %s\n*Template:* %s\n*Interval:* %d minutes",
+		"autobio.variables":     "*Template Variables:*\n• {time} - Current time (HH:MM)\n• {status} - Bot status\n• {web} - Website URL\n• {uptime} - Bot uptime\n• {commands} - Command count\n• {users} - User count\n• {groups} - Group count",
+		"autobio.toggled_on":    "✅ Auto update bio enabled",
+		"autobio.toggled_off":   "❌ Auto update bio disabled",
+		"autobio.need_template": "❎ Please provide a template text\n\nExample: .autobio template 🤖 Bot Online | ⏰ {time} | 📊 {status}",
+		"autobio.template_set":  "✅ Bio template updated:\n%s",
+		"autobio.bad_interval":  "❎ Invalid interval. Must be a positive number",
+		"autobio.interval_fail": "❎ Failed to set interval: %v",
+		"autobio.interval_set":  "✅ Bio update interval set to %d minutes",
+		"autobio.update_fail":   "❎ Failed to update bio: %v",
+		"autobio.updated":       "✅ Bio updated successfully",
+
+		// Downloader commands
+		"desc.play":               "Download YouTube videos as MP3 audio",
+		"desc.tiktok":             "Download TikTok videos without watermark",
+		"desc.ytsearch":           "Search YouTube videos",
+		"arg.play.query":          "YouTube URL or song title",
+		"arg.tiktok.url":          "TikTok video or slide link",
+		"arg.ytsearch.query":      "Search keywords",
+		"downloader.unavailable":  "❎ Downloader system not available. Please try again.",
+		"play.search_failed":      "❎ Failed to search video: %v",
+		"play.download_error":     "❎ An error occurred while downloading the audio!",
+		"play.fetch_failed":       "❎ Failed to download audio data",
+		"play.send_doc_failed":    "❎ Failed to send audio document",
+		"play.send_audio_failed":  "❎ Failed to send audio message",
+		"play.unknown_title":      "Unknown Title",
+		"play.caption":            "*🎵 YT PLAY*\n\n◦ VideoID : %s\n◦ Title : %s\n◦ Duration : %s\n◦ Views : %s\n◦ Author : %s\n◦ Published : %s\n◦ URL : %s",
+		"tiktok.invalid":          "❎ Make sure the link comes from TikTok",
+		"tiktok.download_error":   "❎ Error downloading video",
+		"tiktok.default_title":    "TikTok Video",
+		"tiktok.caption":          "┌─⊷ TIKTOK\n▢ *Description:* %s\n▢ *URL:* %s\n└───────────",
+		"tiktok.slide_caption":    "┌─⊷ TIKTOK SLIDE\n▢ *Description:* %s\n▢ *URL:* %s\n└───────────",
+		"tiktok.slide_detected":   "📱 *TikTok Slide Detected*\n\n▢ *Total Images:* %d\n▢ *Title:* %s\n\n⏳ Downloading and sending slides...",
+		"tiktok.image_fetch_fail": "❎ Failed to download image %d/%d",
+		"tiktok.image_send_fail":  "❎ Failed to send image %d/%d",
+		"tiktok.slide_done":       "✅ *TikTok Slide Done*\n\n▢ *Total Images Sent:* %d/%d\n▢ *Title:* %s",
+		"tiktok.audio_send_fail":  "❎ Failed to send audio",
+		"tiktok.video_fetch_fail": "❎ Failed to download video data",
+		"tiktok.video_send_fail":  "❎ Failed to send video",
+		"ytsearch.failed":         "❎ Search failed: %v",
+		"ytsearch.header":         "*🔍 YouTube Search Results*\n*Query:* %s\n",
+		"ytsearch.entry":          "*%d.* %s\n⏱️ *Duration:* %s\n👁️ *Views:* %s\n📅 *Published:* %s\n👤 *Author:* %s\n🔗 *URL:* %s\n\n",
+		"ytsearch.footer":         "\n*Total Results:* %d\n*Reply with a number or use .play <URL> to download audio*",
+		"ytsearch.select":         "🎵 Choose a result",
+	})
+}
@@ -0,0 +1,241 @@
+package locales
+
+func init() {
+	Register(ID, map[string]string{
+		// Common
+		"common.wait":           "_*Tunggu sedang di proses...*_",
+		"common.error":          "_*Server Error*_",
+		"common.correct":        "Benar ✅\n",
+		"common.wrong":          "Salah ❌\n",
+		"common.unknown":        "Tidak diketahui",
+		"common.db_unavailable": "❎ Database tidak tersedia",
+		"common.no_client":      "❎ Client tidak tersedia untuk mengirim media",
+		"common.cancelled":      "🚫 Dibatalkan",
+		"common.no_answer":      "⏰ Tidak ada jawaban, silakan mulai lagi",
+		"sticker.wait":          "*⫹⫺ Stiker sedang dibuat...*",
+
+		// Dispatcher
+		"cmd.exec_error":     "Terjadi kesalahan saat menjalankan perintah",
+		"cmd.query_required": "Query diperlukan",
+		"cmd.group_only":     "Perintah hanya bisa digunakan di Grup",
+		"cmd.private_only":   "Perintah hanya bisa digunakan di Chat Pribadi",
+		"cmd.media_required": "Balas pesan media, atau kirim media dengan perintah",
+		"cmd.cooldown":       "⏳ Tunggu %d detik sebelum menggunakan %s%s lagi",
+		"cmd.busy":           "⏳ Bot sedang sibuk, silakan coba lagi sebentar lagi",
+		"cmd.suggest":        "❓ Mungkin maksud kamu *%s%s*?",
+
+		// Permissions
+		"perm.owner":      "❎ Perintah ini khusus owner bot",
+		"perm.mod":        "❎ Perintah ini khusus moderator bot",
+		"perm.premium":    "❎ Perintah ini khusus pengguna premium",
+		"perm.admin":      "❎ Perintah ini khusus admin grup",
+		"perm.registered": "❎ Kamu harus daftar dulu untuk menggunakan perintah ini",
+		"perm.bot_admin":  "❎ Bot harus menjadi admin grup untuk menggunakan perintah ini",
+		"perm.group_info": "❎ Gagal mengambil daftar admin grup, silakan coba lagi",
+
+		// Arguments
+		"args.usage":    "*Penggunaan:*",
+		"args.missing":  "%s belum diisi",
+		"args.number":   "%s harus berupa angka",
+		"args.duration": "%s harus berupa durasi seperti 30s, 5m, 1h atau 1d",
+		"args.url":      "%s harus berupa URL yang valid",
+		"args.jid":      "%s harus berupa mention atau nomor telepon",
+		"args.enum":     "%s harus salah satu dari: %s",
+
+		// Sessions
+		"session.confirm": "Balas *ya* atau *tidak*",
+
+		// Main commands
+		"desc.menu":         "Tampilkan menu bot",
+		"desc.help":         "Tampilkan bantuan dan panduan penggunaan",
+		"desc.cancel":       "Batalkan perintah kamu yang sedang berjalan di chat ini",
+		"desc.register":     "Daftarkan nama dan umur kamu langkah demi langkah",
+		"desc.language":     "Ubah bahasa bot untuk kamu atau chat ini",
+		"arg.language":      "Kode bahasa",
+		"arg.language.chat": "Terapkan ke seluruh chat (admin grup)",
+		"cancel.none":       "❎ Tidak ada perintah kamu yang sedang berjalan di chat ini",
+		"cancel.done":       "✅ %d perintah dibatalkan",
+		"ping.result":       "*Ping :* %.2f Detik\n",
+		"language.current":  "🌐 *Bahasa*\n\n*Kamu:* %s\n*Chat ini:* %s\n*Tersedia:* %s\n\n%s",
+		"language.unset":    "belum diatur",
+		"language.invalid":  "❎ Bahasa '%s' tidak didukung. Tersedia: %s",
+		"language.user":     "✅ Bahasa kamu sekarang *%s*",
+		"language.chat":     "✅ Bahasa chat ini sekarang *%s*",
+
+		"register.already":      "ℹ️ Kamu sudah terdaftar sebagai *%s*",
+		"register.ask_name":     "📝 Siapa nama kamu?\n\n_Ketik *batal* untuk berhenti_",
+		"register.name_invalid": "❎ Nama harus 1 sampai 32 karakter",
+		"register.ask_age":      "🎂 Berapa umur kamu?",
+		"register.age_invalid":  "❎ Umur harus berupa angka antara 5 dan 100",
+		"register.confirm":      "Daftar sebagai *%s*, %d tahun?",
+		"register.cancelled":    "🚫 Pendaftaran dibatalkan",
+		"register.done":         "✅ Terdaftar sebagai *%s*, %d tahun",
+
+		"perf.report": "📊 Laporan Performa\n" +
+			"═══════════════════════\n\n" +
+			"⏱️  Uptime: %s\n" +
+			"📨 Pesan: %d (%s/menit)\n" +
+			"⚡ Perintah: %d (%s/menit)\n" +
+			"❌ Error: %d (%s/menit)\n" +
+			"💾 Operasi DB: %d (%s/menit)\n" +
+			"🌐 Request HTTP: %d (%s/menit)\n" +
+			"🎯 Cache Hit Rate: %s\n" +
+			"🧠 Memori: %s\n" +
+			"🔄 Goroutine: %d\n" +
+			"🗑️  Jumlah GC: %d\n" +
+			"📥 Antrean: %d menunggu, %d ditolak (rata-rata %s, maks %s)\n",
+
+		// Menu
+		"menu.divider":           "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n",
+		"menu.title":             "🎯 *ZUMYGO BOT MENU*\n",
+		"menu.main_title":        "🎯 *ZUMYGO BOT - MENU UTAMA*\n",
+		"menu.help_title":        "📚 *ZUMYGO BOT - BANTUAN & PANDUAN*\n",
+		"menu.list_title":        "🎯 *ZUMYGO BOT - DAFTAR PERINTAH*\n",
+		"menu.user":              "👤 Pengguna: %s\n",
+		"menu.welcome":           "👤 Selamat datang, %s!\n",
+		"menu.tagline":           "🤖 Asisten WhatsApp kamu\n",
+		"menu.category":          "📱 Kategori: %s\n",
+		"menu.no_commands":       "❌ Tidak ada perintah di kategori ini.\n💡 Coba: .menu untuk melihat semua kategori\n",
+		"menu.no_description":    "Tidak ada deskripsi",
+		"menu.categories":        "*📋 Kategori Tersedia:*\n\n",
+		"menu.category_count":    "   └ %d perintah tersedia\n\n",
+		"menu.navigation":        "💡 *Navigasi:*\n• .menu - Tampilkan menu utama\n• .menu [kategori] - Tampilkan kategori tertentu\n• .help - Tampilkan bantuan\n• Contoh: .menu downloader\n\n",
+		"menu.how_to":            "💡 *Cara pakai:*\n• Balas dengan angka untuk membuka kategori\n• .menu [kategori] - Lihat kategori tertentu\n• .help - Tampilkan bantuan\n• Contoh: .menu downloader\n• Contoh: .menu owner\n\n",
+		"menu.status":            "🔧 *Status Bot:* Online ✅\n📊 *Versi:* 2.0 Professional\n🌟 *Fitur:* Interaktif & Cepat",
+		"menu.powered":           "🔧 Powered by ZUMYGO Bot",
+		"menu.quick_start":       "*🚀 Panduan Cepat:*\n\n1. *Menu Utama:* .menu\n2. *Menu Kategori:* .menu [kategori]\n3. *Bantuan:* .help\n\n",
+		"menu.popular":           "*🔥 Perintah Populer:*\n\n📥 *Perintah Download:*\n• .p [query] - Download MP3 YouTube\n• .tt [url] - Download video TikTok\n• .yt [query] - Cari di YouTube\n\n🏠 *Perintah Utama:*\n• .menu - Tampilkan menu utama\n• .ping - Cek status bot\n• .perf - Tampilkan statistik performa\n\n",
+		"menu.category_examples": "*📂 Contoh Kategori:*\n\n",
+		"menu.more_help":         "💡 *Butuh bantuan lagi?*\n• Hubungi owner bot\n• Cek .menu untuk semua perintah\n",
+		"menu.list_usage":        "💡 *Penggunaan:* .menu [kategori]\n",
+		"menu.select":            "📋 Pilih kategori",
+		"menu.row_count":         "%d perintah",
+
+		// Group commands
+		"desc.disable":        "Nonaktifkan perintah atau tag di chat ini atau secara global",
+		"desc.enable":         "Aktifkan kembali perintah atau tag yang dinonaktifkan",
+		"desc.disabled":       "Daftar perintah yang dinonaktifkan di chat ini dan global",
+		"desc.suggest":        "Nyalakan atau matikan saran \"mungkin maksud kamu\" di chat ini",
+		"arg.toggle.target":   "Nama perintah, alias atau tag",
+		"arg.toggle.global":   "Terapkan ke semua chat (khusus owner)",
+		"arg.suggest.state":   "Apakah perintah yang salah ketik diberi saran",
+		"toggle.not_found":    "❎ Tidak ada perintah atau tag bernama '%s'",
+		"toggle.locked":       "❎ %s tidak bisa dinonaktifkan",
+		"toggle.already":      "ℹ️ %s sudah %s %s",
+		"toggle.global_off":   "⚠️ %s dinonaktifkan secara global oleh owner, jadi tetap nonaktif di sini",
+		"toggle.done":         "✅ %s %s %s",
+		"toggle.enabled":      "diaktifkan",
+		"toggle.disabled":     "dinonaktifkan",
+		"toggle.scope_chat":   "di chat ini",
+		"toggle.scope_global": "secara global",
+		"toggle.list_title":   "*🚫 PERINTAH NONAKTIF*\n\n",
+		"toggle.list_global":  "Global",
+		"toggle.list_chat":    "Chat ini",
+		"toggle.list_none":    "*%s:* tidak ada\n",
+		"suggest.on":          "✅ Saran perintah dinyalakan di chat ini",
+		"suggest.off":         "✅ Saran perintah dimatikan di chat ini",
+		"desc.prefix":         "Lihat atau ubah prefix perintah di chat ini",
+		"arg.prefix.action":   "Set prefix baru, reset ke bawaan atau ubah mode tanpa prefix",
+		"arg.prefix.value":    "Prefix dipisahkan spasi",
+		"prefix.current":      "*🔣 PREFIX*\n\nPrefix: %s\nMode tanpa prefix: %s\nMention bot selalu bisa dipakai sebagai prefix.\n\n%s",
+		"prefix.state_on":     "aktif",
+		"prefix.state_off":    "mati",
+		"prefix.set":          "✅ Prefix di chat ini diubah menjadi: %s",
+		"prefix.reset":        "✅ Prefix di chat ini dikembalikan ke bawaan: %s",
+		"prefix.too_long":     "❎ Prefix '%s' lebih dari %d karakter",
+		"prefix.none_on":      "✅ Perintah di chat ini sekarang bisa tanpa prefix",
+		"prefix.none_off":     "✅ Perintah di chat ini kembali memakai prefix",
+
+		"desc.antidelete":      "Kirim ulang pesan yang dihapus di chat ini (aktif bawaan di grup, nonaktif di chat pribadi)",
+		"arg.antidelete.state": "Nyalakan atau matikan anti-delete",
+		"antidelete.current":   "*🗑️ ANTI-DELETE*\n\nStatus: %s\n\n%s",
+		"antidelete.state_on":  "aktif",
+		"antidelete.state_off": "nonaktif",
+		"antidelete.on":        "✅ Pesan yang dihapus di chat ini akan dikirim ulang",
+		"antidelete.off":       "✅ Pesan yang dihapus di chat ini tidak lagi dikirim ulang",
+		"antidelete.notice":    "🗑️ @%s menghapus:",
+
+		// Owner commands
+		"desc.schedule":         "Jadwalkan pesan atau perintah sekali",
+		"desc.cron":             "Jadwalkan pesan atau perintah berulang",
+		"desc.jobs":             "Lihat daftar jadwal",
+		"desc.unschedule":       "Batalkan jadwal",
+		"arg.schedule.when":     "Jeda seperti 30m atau 2h, jam HH:MM, atau tanggal YYYY-MM-DD[THH:MM]",
+		"arg.schedule.to":       "JID atau nomor chat tujuan; bawaan chat ini",
+		"arg.schedule.cmd":      "Jalankan teks sebagai perintah, bukan dikirim",
+		"arg.schedule.text":     "Pesan atau perintah yang dikirim",
+		"arg.cron.expr":         "Lima kolom cron (menit jam tanggal bulan hari) atau @hourly, @daily, @weekly, @monthly, lalu teksnya",
+		"arg.unschedule.id":     "ID jadwal dari daftar jobs",
+		"schedule.bad_time":     "❎ '%s' bukan jeda, jam atau tanggal yang valid",
+		"schedule.bad_cron":     "❎ Ekspresi cron tidak valid: %s",
+		"schedule.bad_chat":     "❎ '%s' bukan chat yang valid",
+		"schedule.past":         "❎ Waktu tersebut sudah lewat",
+		"schedule.never":        "❎ Ekspresi cron tersebut tidak pernah berjalan",
+		"schedule.done":         "✅ Jadwal *%s* dibuat, berjalan berikutnya %s di %s",
+		"schedule.none":         "ℹ️ Tidak ada jadwal",
+		"schedule.once":         "sekali",
+		"schedule.kind_message": "pesan",
+		"schedule.kind_command": "perintah",
+		"schedule.list_title":   "*⏰ DAFTAR JADWAL*\n\n",
+		"schedule.list_item":    "*%s* · %s · %s\nChat: %s\nBerikutnya: %s\n> %s\n\n",
+		"schedule.list_footer":  "Batalkan dengan %sunschedule <id>",
+		"schedule.not_found":    "❎ Tidak ada jadwal dengan ID '%s'",
+		"schedule.cancelled":    "✅ Jadwal *%s* dibatalkan",
+		"desc.autobio":          "Atur sistem pembaruan bio otomatis",
+		"desc.mode":             "Ganti antara mode publik dan privat",
+		"arg.autobio.action":    "Nyalakan, atur atau paksa pembaruan bio",
+		"arg.autobio.value":     "Teks template atau interval dalam menit",
+		"arg.autobio.minutes":   "Interval dalam menit",
+		"mode.private":          "Bot sekarang dalam mode privat.",
+		"mode.public":           "Bot sekarang dalam mode publik.",
+		"autobio.unavailable":   "❎ Sistem bio tidak tersedia",
+		"autobio.enabled":       "✅ Aktif",
+		"autobio.disabled":      "❌ Nonaktif",
+		"autobio.running":       "✅ Berjalan",
+		"autobio.stopped":       "❌ Berhenti",
+		"autobio.status":        "*📝 Status Sistem Auto Bio*\n\n*Status:* %s\n*Berjalan:* %s\n*Template:* %s\n*Interval:* %d menit",
+		"autobio.variables":     "*Variabel Template:*\n• {time} - Waktu sekarang (HH:MM)\n• {status} - Status bot\n• {web} - URL website\n• {uptime} - Uptime bot\n• {commands} - Jumlah perintah\n• {users} - Jumlah pengguna\n• {groups} - Jumlah grup",
+		"autobio.toggled_on":    "✅ Update bio otomatis diaktifkan",
+		"autobio.toggled_off":   "❌ Update bio otomatis dinonaktifkan",
+		"autobio.need_template": "❎ Masukkan teks template\n\nContoh: .autobio template 🤖 Bot Online | ⏰ {time} | 📊 {status}",
+		"autobio.template_set":  "✅ Template bio diperbarui:\n%s",
+		"autobio.bad_interval":  "❎ Interval tidak valid. Harus angka positif",
+		"autobio.interval_fail": "❎ Gagal mengatur interval: %v",
+		"autobio.interval_set":  "✅ Interval update bio diatur ke %d menit",
+		"autobio.update_fail":   "❎ Gagal memperbarui bio: %v",
+		"autobio.updated":       "✅ Bio berhasil diperbarui",
+
+		// Downloader commands
+		"desc.play":               "Download video YouTube sebagai audio MP3",
+		"desc.tiktok":             "Download video TikTok tanpa watermark",
+		"desc.ytsearch":           "Cari video YouTube",
+		"arg.play.query":          "URL YouTube atau judul lagu",
+		"arg.tiktok.url":          "Link video atau slide TikTok",
+		"arg.ytsearch.query":      "Kata kunci pencarian",
+		"downloader.unavailable":  "❎ Sistem downloader tidak tersedia. Silakan coba lagi.",
+		"play.search_failed":      "❎ Gagal mencari video: %v",
+		"play.download_error":     "❎ Terjadi kesalahan saat mengunduh audio!",
+		"play.fetch_failed":       "❎ Gagal mengunduh data audio",
+		"play.send_doc_failed":    "❎ Gagal mengirim audio document",
+		"play.send_audio_failed":  "❎ Gagal mengirim audio message",
+		"play.unknown_title":      "Judul Tidak Diketahui",
+		"play.caption":            "*🎵 YT PLAY*\n\n◦ VideoID : %s\n◦ Judul : %s\n◦ Durasi : %s\n◦ Ditonton : %s\n◦ Pembuat : %s\n◦ Diunggah : %s\n◦ URL : %s",
+		"tiktok.invalid":          "❎ Verifikasi bahwa tautan tersebut berasal dari TikTok",
+		"tiktok.download_error":   "❎ Kesalahan mengunduh video",
+		"tiktok.default_title":    "Video TikTok",
+		"tiktok.caption":          "┌─⊷ TIKTOK\n▢ *Deskripsi:* %s\n▢ *URL:* %s\n└───────────",
+		"tiktok.slide_caption":    "┌─⊷ TIKTOK SLIDE\n▢ *Deskripsi:* %s\n▢ *URL:* %s\n└───────────",
+		"tiktok.slide_detected":   "📱 *TikTok Slide Terdeteksi*\n\n▢ *Total Gambar:* %d\n▢ *Judul:* %s\n\n⏳ Sedang mengunduh dan mengirim slide...",
+		"tiktok.image_fetch_fail": "❎ Gagal mengunduh image %d/%d",
+		"tiktok.image_send_fail":  "❎ Gagal mengirim image %d/%d",
+		"tiktok.slide_done":       "✅ *TikTok Slide Berhasil*\n\n▢ *Total Gambar Terkirim:* %d/%d\n▢ *Judul:* %s",
+		"tiktok.audio_send_fail":  "❎ Gagal mengirim audio",
+		"tiktok.video_fetch_fail": "❎ Gagal mengunduh data video",
+		"tiktok.video_send_fail":  "❎ Gagal mengirim video",
+		"ytsearch.failed":         "❎ Gagal melakukan pencarian: %v",
+		"ytsearch.header":         "*🔍 Hasil Pencarian YouTube*\n*Query:* %s\n",
+		"ytsearch.entry":          "*%d.* %s\n⏱️ *Durasi:* %s\n👁️ *Ditonton:* %s\n📅 *Diunggah:* %s\n👤 *Pembuat:* %s\n🔗 *URL:* %s\n\n",
+		"ytsearch.footer":         "\n*Total Hasil:* %d\n*Balas dengan angka atau gunakan .play <URL> untuk download audio*",
+		"ytsearch.select":         "🎵 Pilih hasil",
+	})
+}
@@ -0,0 +1,89 @@
+package locales
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Supported languages
+const (
+	ID = "id"
+	EN = "en"
+)
+
+// Default is the language used when neither the user, the chat nor the
+// configuration chooses one
+const Default = ID
+
+var (
+	bundles     = make(map[string]map[string]string)
+	bundleMutex sync.RWMutex
+)
+
+// Register adds messages to a language bundle, replacing existing keys
+func Register(lang string, messages map[string]string) {
+	bundleMutex.Lock()
+	defer bundleMutex.Unlock()
+
+	lang = strings.ToLower(lang)
+	bundle, exists := bundles[lang]
+	if !exists {
+		bundle = make(map[string]string, len(messages))
+		bundles[lang] = bundle
+	}
+	for key, value := range messages {
+		bundle[key] = value
+	}
+}
+
+// Override replaces one message of a bundle, ignoring empty values
+func Override(lang, key, value string) {
+	if value == "" {
+		return
+	}
+	Register(lang, map[string]string{key: value})
+}
+
+// T returns the message for a key in the given language, formatted with the
+// arguments. Missing keys fall back to English and then to the key itself,
+// so plain text can be passed where a key is expected.
+func T(lang, key string, args ...interface{}) string {
+	bundleMutex.RLock()
+	message, ok := bundles[strings.ToLower(lang)][key]
+	if !ok {
+		message, ok = bundles[EN][key]
+	}
+	bundleMutex.RUnlock()
+
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// IsSupported reports whether a bundle exists for the language
+func IsSupported(lang string) bool {
+	bundleMutex.RLock()
+	defer bundleMutex.RUnlock()
+
+	_, exists := bundles[strings.ToLower(lang)]
+	return exists
+}
+
+// Supported returns the codes of every registered language
+func Supported() []string {
+	bundleMutex.RLock()
+	defer bundleMutex.RUnlock()
+
+	var result []string
+	for lang := range bundles {
+		result = append(result, lang)
+	}
+	sort.Strings(result)
+	return result
+}
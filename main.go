@@ -52,8 +52,8 @@ func main() {
 	systems.SetGlobalDownloaderSystem(downloaderSystem)
 	logger.Info("Downloader system initialized successfully")
 
-	// Bio system is auto-initialized via Before hook
-	logger.Info("Bio system auto-initialized via Before hook")
+	// Bio system is auto-initialized via event listener
+	logger.Info("Bio system auto-initialized via event listener")
 
 	// Print startup information
 	printStartupInfo()
@@ -0,0 +1,142 @@
+// Package phone normalizes phone numbers as typed by users, written in the
+// config or carried by WhatsApp JIDs into one comparable form: the E.164
+// digits without the leading plus, which is also the user part of a JID.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultCountry is the calling code used for national numbers, written
+// with a leading 0, when no other is configured
+const DefaultCountry = "62"
+
+// Bounds of a full number, country code included, per E.164
+const (
+	minDigits = 7
+	maxDigits = 15
+)
+
+var (
+	ErrEmpty   = errors.New("phone number is empty")
+	ErrInvalid = errors.New("phone number contains invalid characters")
+	ErrLength  = errors.New("phone number has an invalid length")
+	ErrServer  = errors.New("JID is not a phone number JID")
+)
+
+// phoneServers are the JID servers whose user part is a phone number
+var phoneServers = map[string]bool{
+	"s.whatsapp.net": true,
+	"c.us":           true,
+}
+
+// Normalize returns the number in E.164 digits without the plus, reading
+// national numbers with DefaultCountry
+func Normalize(input string) (string, error) {
+	return NormalizeWithCountry(input, DefaultCountry)
+}
+
+// NormalizeWithCountry returns the number in E.164 digits without the plus.
+// It accepts international numbers with +, 00 or a bare country code,
+// national numbers with a leading 0 read in the given country, common
+// separators (spaces, dashes, dots, parentheses, slashes), and phone number
+// JIDs with or without a device suffix.
+func NormalizeWithCountry(input, country string) (string, error) {
+	number := strings.TrimSpace(input)
+	if number == "" {
+		return "", ErrEmpty
+	}
+
+	// JIDs: user[.agent][:device]@server
+	if at := strings.IndexByte(number, '@'); at >= 0 {
+		if !phoneServers[strings.ToLower(number[at+1:])] {
+			return "", ErrServer
+		}
+		number = number[:at]
+		if dot := strings.IndexByte(number, '.'); dot >= 0 {
+			number = number[:dot]
+		}
+	}
+	if colon := strings.IndexByte(number, ':'); colon >= 0 {
+		number = number[:colon]
+	}
+
+	number = strings.TrimSpace(number)
+	international := strings.HasPrefix(number, "+")
+	number = strings.TrimPrefix(number, "+")
+
+	var digits strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/' || r == '\u00a0':
+		default:
+			return "", ErrInvalid
+		}
+	}
+
+	result := digits.String()
+	if result == "" {
+		return "", ErrEmpty
+	}
+
+	if !international {
+		switch {
+		case strings.HasPrefix(result, "00"):
+			result = result[2:]
+		case strings.HasPrefix(result, "0"):
+			if country = strings.TrimPrefix(strings.TrimSpace(country), "+"); country == "" {
+				country = DefaultCountry
+			}
+			result = country + result[1:]
+		}
+	}
+
+	if strings.HasPrefix(result, "0") {
+		return "", ErrInvalid
+	}
+	if len(result) < minDigits || len(result) > maxDigits {
+		return "", ErrLength
+	}
+	return result, nil
+}
+
+// E164 returns the number in E.164 form, with the leading plus
+func E164(input string) (string, error) {
+	number, err := Normalize(input)
+	if err != nil {
+		return "", err
+	}
+	return "+" + number, nil
+}
+
+// Equal reports whether two inputs are the same valid number
+func Equal(a, b string) bool {
+	return EqualWithCountry(a, b, DefaultCountry)
+}
+
+// EqualWithCountry is Equal reading national numbers in the given country
+func EqualWithCountry(a, b, country string) bool {
+	first, err := NormalizeWithCountry(a, country)
+	if err != nil {
+		return false
+	}
+	second, err := NormalizeWithCountry(b, country)
+	return err == nil && first == second
+}
+
+// Match reports whether number is one of list, skipping invalid entries
+func Match(list []string, number, country string) bool {
+	target, err := NormalizeWithCountry(number, country)
+	if err != nil {
+		return false
+	}
+	for _, entry := range list {
+		if normalized, err := NormalizeWithCountry(entry, country); err == nil && normalized == target {
+			return true
+		}
+	}
+	return false
+}
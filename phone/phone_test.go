@@ -0,0 +1,134 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		// International forms
+		{"6281234567890", "6281234567890", nil},
+		{"+6281234567890", "6281234567890", nil},
+		{"+62 812-3456-7890", "6281234567890", nil},
+		{"+62 (812) 3456.7890", "6281234567890", nil},
+		{"006281234567890", "6281234567890", nil},
+		{"+1 415 555 2671", "14155552671", nil},
+		{"+44 20 7946 0958", "442079460958", nil},
+		{"  6281234567890  ", "6281234567890", nil},
+		{"62 812 34567890", "6281234567890", nil},
+
+		// National forms read in the default country
+		{"081234567890", "6281234567890", nil},
+		{"0812-3456-7890", "6281234567890", nil},
+		{"0812 3456 7890", "6281234567890", nil},
+
+		// JIDs and device suffixes
+		{"6281234567890@s.whatsapp.net", "6281234567890", nil},
+		{"6281234567890:12@s.whatsapp.net", "6281234567890", nil},
+		{"6281234567890.0:3@s.whatsapp.net", "6281234567890", nil},
+		{"6281234567890@c.us", "6281234567890", nil},
+		{"6281234567890:7", "6281234567890", nil},
+		{"123456789@lid", "", ErrServer},
+		{"120363000000000001@g.us", "", ErrServer},
+
+		// Invalid input
+		{"", "", ErrEmpty},
+		{"   ", "", ErrEmpty},
+		{"+", "", ErrEmpty},
+		{"-- --", "", ErrEmpty},
+		{"62812abc7890", "", ErrInvalid},
+		{"+62 812 3456 7890 ext 1", "", ErrInvalid},
+		{"#6281234567890", "", ErrInvalid},
+		{"+0812345678", "", ErrInvalid},
+		{"62812", "", ErrLength},
+		{"0812", "", ErrLength},
+		{"6281234567890123", "", ErrLength},
+	}
+
+	for _, c := range cases {
+		got, err := Normalize(c.input)
+		if got != c.want || !errors.Is(err, c.err) {
+			t.Errorf("Normalize(%q) = %q, %v; want %q, %v", c.input, got, err, c.want, c.err)
+		}
+	}
+}
+
+func TestNormalizeWithCountry(t *testing.T) {
+	cases := []struct {
+		input, country, want string
+	}{
+		{"07911 123456", "44", "447911123456"},
+		{"07911 123456", "+44", "447911123456"},
+		{"0612345678", "31", "31612345678"},
+		{"0812345678", "", DefaultCountry + "812345678"},
+		{"+6281234567890", "44", "6281234567890"},
+		{"006281234567890", "44", "6281234567890"},
+	}
+
+	for _, c := range cases {
+		if got, err := NormalizeWithCountry(c.input, c.country); err != nil || got != c.want {
+			t.Errorf("NormalizeWithCountry(%q, %q) = %q, %v; want %q", c.input, c.country, got, err, c.want)
+		}
+	}
+}
+
+func TestE164(t *testing.T) {
+	if got, err := E164("0812-3456-7890"); err != nil || got != "+6281234567890" {
+		t.Errorf("E164 = %q, %v", got, err)
+	}
+	if _, err := E164("abc"); err == nil {
+		t.Errorf("E164 accepted an invalid number")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"+62 812-3456-7890", "081234567890", true},
+		{"6281234567890:3@s.whatsapp.net", "+6281234567890", true},
+		{"6281234567890", "6281234567891", false},
+		{"628123456", "6281234567890", false},
+		{"", "", false},
+		{"abc", "abc", false},
+	}
+
+	for _, c := range cases {
+		if got := Equal(c.a, c.b); got != c.want {
+			t.Errorf("Equal(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	list := []string{"", "not a number", "+62 851-2386-5643", "0812 3456 7890"}
+
+	cases := []struct {
+		number string
+		want   bool
+	}{
+		{"6285123865643", true},
+		{"6285123865643@s.whatsapp.net", true},
+		{"6285123865643:21@s.whatsapp.net", true},
+		{"6281234567890", true},
+		// A prefix of a listed number is a different number
+		{"62851238", false},
+		{"6285123865643@lid", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := Match(list, c.number, DefaultCountry); got != c.want {
+			t.Errorf("Match(%q) = %v, want %v", c.number, got, c.want)
+		}
+	}
+	if Match(nil, "6285123865643", DefaultCountry) {
+		t.Errorf("Match on an empty list")
+	}
+}
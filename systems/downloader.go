@@ -124,6 +124,15 @@ func InitializeDownloaderSystem(cfg *config.BotConfig, db *database.Database, lo
 	}
 }
 
+// get performs a GET request bound to the caller context
+func (ds *DownloaderSystem) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ds.httpClient.Do(req)
+}
+
 // getCachedResult retrieves cached download result
 func (ds *DownloaderSystem) getCachedResult(key string) (*DownloadResult, bool) {
 	ds.cacheMutex.RLock()
@@ -175,7 +184,7 @@ func (ds *DownloaderSystem) cleanupCache() {
 }
 
 // DownloadMedia handles downloading media from various platforms with caching
-func (ds *DownloaderSystem) DownloadMedia(platform, url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) DownloadMedia(ctx context.Context, platform, url string) (*DownloadResult, error) {
 	// Add nil checks for safety
 	if ds == nil {
 		return &DownloadResult{Success: false, Error: "Downloader system is nil"}, fmt.Errorf("downloader system is nil")
@@ -201,57 +210,33 @@ func (ds *DownloaderSystem) DownloadMedia(platform, url string) (*DownloadResult
 	var result *DownloadResult
 	var err error
 	
-	// Use context with timeout for faster failure detection
-	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second) // Slightly less than client timeout
+	// Bound the download by the caller context, failing fast on slow APIs
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Second) // Slightly less than client timeout
 	defer cancel()
 	
-	// Create channel for concurrent processing
-	resultChan := make(chan *DownloadResult, 1)
-	errChan := make(chan error, 1)
+	switch strings.ToLower(platform) {
+	case "youtube", "yt":
+		result, err = ds.downloadYouTube(ctx, url)
+	case "instagram", "ig":
+		result, err = ds.downloadInstagram(ctx, url)
+	case "tiktok", "tt":
+		result, err = ds.downloadTikTok(ctx, url)
+	case "facebook", "fb":
+		result, err = ds.downloadFacebook(ctx, url)
+	case "twitter", "x":
+		result, err = ds.downloadTwitter(ctx, url)
+	case "telegram":
+		result, err = ds.downloadTelegram(ctx, url)
+	default:
+		result, err = ds.downloadGeneric(ctx, url)
+	}
 	
-	// Start download in goroutine
-	go func() {
-		var downloadResult *DownloadResult
-		var downloadErr error
-		
-		switch strings.ToLower(platform) {
-		case "youtube", "yt":
-			downloadResult, downloadErr = ds.downloadYouTube(url)
-		case "instagram", "ig":
-			downloadResult, downloadErr = ds.downloadInstagram(url)
-		case "tiktok", "tt":
-			downloadResult, downloadErr = ds.downloadTikTok(url)
-		case "facebook", "fb":
-			downloadResult, downloadErr = ds.downloadFacebook(url)
-		case "twitter", "x":
-			downloadResult, downloadErr = ds.downloadTwitter(url)
-		case "telegram":
-			downloadResult, downloadErr = ds.downloadTelegram(url)
-		default:
-			downloadResult, downloadErr = ds.downloadGeneric(url)
-		}
-		
-		select {
-		case resultChan <- downloadResult:
-		case <-ctx.Done():
-			return
-		}
-		
-		select {
-		case errChan <- downloadErr:
-		case <-ctx.Done():
-			return
+	// Do not cache results of cancelled or timed out downloads
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if ctxErr == context.DeadlineExceeded {
+			return &DownloadResult{Success: false, Error: "Download timeout"}, fmt.Errorf("download timeout")
 		}
-	}()
-	
-	// Wait for result or timeout
-	select {
-	case result = <-resultChan:
-		err = <-errChan
-	case err = <-errChan:
-		result = <-resultChan
-	case <-ctx.Done():
-		return &DownloadResult{Success: false, Error: "Download timeout"}, fmt.Errorf("download timeout")
+		return &DownloadResult{Success: false, Error: "Download cancelled"}, ctxErr
 	}
 	
 	// Cache the result
@@ -263,7 +248,7 @@ func (ds *DownloaderSystem) DownloadMedia(platform, url string) (*DownloadResult
 }
 
 // downloadYouTube downloads YouTube videos/audio with optimized HTTP client
-func (ds *DownloaderSystem) downloadYouTube(videoURL string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadYouTube(ctx context.Context, videoURL string) (*DownloadResult, error) {
 	// Use betabotz API for audio download
 	encodedURL := url.QueryEscape(videoURL)
 	apiURL := fmt.Sprintf("https://api.betabotz.eu.org/api/download/ytmp3?url=%s&apikey=%s", 
@@ -272,7 +257,7 @@ func (ds *DownloaderSystem) downloadYouTube(videoURL string) (*DownloadResult, e
 	ds.logger.Info(fmt.Sprintf("Calling API: %s", apiURL))
 	
 	// Create request with browser-like headers
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		errorMsg := fmt.Sprintf("Failed to create request: %v", err)
 		ds.logger.Error(errorMsg)
@@ -400,12 +385,12 @@ func (ds *DownloaderSystem) downloadYouTube(videoURL string) (*DownloadResult, e
 }
 
 // downloadInstagram downloads Instagram posts with optimized client
-func (ds *DownloaderSystem) downloadInstagram(url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadInstagram(ctx context.Context, url string) (*DownloadResult, error) {
 	apiURL := ds.cfg.API("tio", "/api/instagram", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return &DownloadResult{Success: false, Error: "Failed to fetch Instagram data"}, err
 	}
@@ -432,7 +417,7 @@ func (ds *DownloaderSystem) downloadInstagram(url string) (*DownloadResult, erro
 }
 
 // downloadTikTok downloads TikTok videos with optimized client
-func (ds *DownloaderSystem) downloadTikTok(tiktokURL string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadTikTok(ctx context.Context, tiktokURL string) (*DownloadResult, error) {
 	// Use betabotz API for TikTok download
 	encodedURL := url.QueryEscape(tiktokURL)
 	apiURL := fmt.Sprintf("https://api.betabotz.eu.org/api/download/tiktok?url=%s&apikey=%s", 
@@ -441,7 +426,7 @@ func (ds *DownloaderSystem) downloadTikTok(tiktokURL string) (*DownloadResult, e
 	ds.logger.Info(fmt.Sprintf("Calling TikTok API: %s", apiURL))
 	
 	// Create request with browser-like headers
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		errorMsg := fmt.Sprintf("Failed to create request: %v", err)
 		ds.logger.Error(errorMsg)
@@ -607,12 +592,12 @@ func (ds *DownloaderSystem) downloadTikTok(tiktokURL string) (*DownloadResult, e
 }
 
 // downloadFacebook downloads Facebook videos with optimized client
-func (ds *DownloaderSystem) downloadFacebook(url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadFacebook(ctx context.Context, url string) (*DownloadResult, error) {
 	apiURL := ds.cfg.API("tio", "/api/facebook", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return &DownloadResult{Success: false, Error: "Failed to fetch Facebook data"}, err
 	}
@@ -638,12 +623,12 @@ func (ds *DownloaderSystem) downloadFacebook(url string) (*DownloadResult, error
 }
 
 // downloadTwitter downloads Twitter/X videos with optimized client
-func (ds *DownloaderSystem) downloadTwitter(url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadTwitter(ctx context.Context, url string) (*DownloadResult, error) {
 	apiURL := ds.cfg.API("tio", "/api/twitter", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return &DownloadResult{Success: false, Error: "Failed to fetch Twitter data"}, err
 	}
@@ -669,12 +654,12 @@ func (ds *DownloaderSystem) downloadTwitter(url string) (*DownloadResult, error)
 }
 
 // downloadTelegram downloads Telegram media with optimized client
-func (ds *DownloaderSystem) downloadTelegram(url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadTelegram(ctx context.Context, url string) (*DownloadResult, error) {
 	apiURL := ds.cfg.API("tio", "/api/telegram", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return &DownloadResult{Success: false, Error: "Failed to fetch Telegram data"}, err
 	}
@@ -700,14 +685,14 @@ func (ds *DownloaderSystem) downloadTelegram(url string) (*DownloadResult, error
 }
 
 // downloadGeneric handles generic URL downloads with optimized client
-func (ds *DownloaderSystem) downloadGeneric(url string) (*DownloadResult, error) {
+func (ds *DownloaderSystem) downloadGeneric(ctx context.Context, url string) (*DownloadResult, error) {
 	// Check if URL is valid
 	if !ds.isValidURL(url) {
 		return &DownloadResult{Success: false, Error: "Invalid URL"}, nil
 	}
 	
 	// Try to get file info with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	
 	req, err := http.NewRequestWithContext(ctx, "HEAD", url, nil)
@@ -733,7 +718,7 @@ func (ds *DownloaderSystem) downloadGeneric(url string) (*DownloadResult, error)
 }
 
 // DownloadFile downloads a file from URL to local storage with progress tracking
-func (ds *DownloaderSystem) DownloadFile(downloadURL, filename string) error {
+func (ds *DownloaderSystem) DownloadFile(ctx context.Context, downloadURL, filename string) error {
 	// Create downloads directory if it doesn't exist
 	downloadsDir := "downloads"
 	if err := os.MkdirAll(downloadsDir, 0755); err != nil {
@@ -748,7 +733,7 @@ func (ds *DownloaderSystem) DownloadFile(downloadURL, filename string) error {
 	filepath := filepath.Join(downloadsDir, filename)
 	
 	// Download the file with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
@@ -773,15 +758,15 @@ func (ds *DownloaderSystem) DownloadFile(downloadURL, filename string) error {
 }
 
 // GetVideoInfo gets information about a video with caching
-func (ds *DownloaderSystem) GetVideoInfo(url string) (*VideoInfo, error) {
+func (ds *DownloaderSystem) GetVideoInfo(ctx context.Context, url string) (*VideoInfo, error) {
 	// Extract platform and get info
 	platform := ds.detectPlatform(url)
 	
 	switch platform {
 	case "youtube":
-		return ds.getYouTubeInfo(url)
+		return ds.getYouTubeInfo(ctx, url)
 	case "tiktok":
-		return ds.getTikTokInfo(url)
+		return ds.getTikTokInfo(ctx, url)
 	default:
 		return &VideoInfo{
 			Title: "Unknown Video",
@@ -790,7 +775,7 @@ func (ds *DownloaderSystem) GetVideoInfo(url string) (*VideoInfo, error) {
 }
 
 // SearchYouTube searches for YouTube videos using betabotz API and returns first result with caching
-func (ds *DownloaderSystem) SearchYouTube(query string) (*SearchResult, error) {
+func (ds *DownloaderSystem) SearchYouTube(ctx context.Context, query string) (*SearchResult, error) {
 	// Add nil checks for safety
 	if ds == nil {
 		return nil, fmt.Errorf("downloader system is nil")
@@ -828,7 +813,7 @@ func (ds *DownloaderSystem) SearchYouTube(query string) (*SearchResult, error) {
 	}
 	
 	// Use context with timeout for faster failure detection
-	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 40*time.Second)
 	defer cancel()
 	
 	// Build search API URL
@@ -931,13 +916,13 @@ func (ds *DownloaderSystem) SearchYouTube(query string) (*SearchResult, error) {
 }
 
 // SearchYouTubeMultiple searches for YouTube videos and returns multiple results
-func (ds *DownloaderSystem) SearchYouTubeMultiple(query string) ([]*SearchResult, error) {
+func (ds *DownloaderSystem) SearchYouTubeMultiple(ctx context.Context, query string) ([]*SearchResult, error) {
 	// Build search API URL
 	searchURL := fmt.Sprintf("https://api.betabotz.eu.org/api/search/yts?query=%s&apikey=%s", 
 		url.QueryEscape(query), ds.cfg.APIKeys["https://api.betabotz.eu.org"])
 
 	// Create request with browser-like headers
-	req, err := http.NewRequest("GET", searchURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -998,7 +983,7 @@ func (ds *DownloaderSystem) SearchYouTubeMultiple(query string) ([]*SearchResult
 }
 
 // SearchYouTubeByURL searches for a specific video by URL
-func (ds *DownloaderSystem) SearchYouTubeByURL(targetURL string) (*SearchResult, error) {
+func (ds *DownloaderSystem) SearchYouTubeByURL(ctx context.Context, targetURL string) (*SearchResult, error) {
 	// Extract video ID from the target URL
 	targetVideoID := ds.extractYouTubeID(targetURL)
 	if targetVideoID == "" {
@@ -1020,7 +1005,7 @@ func (ds *DownloaderSystem) SearchYouTubeByURL(targetURL string) (*SearchResult,
 			url.QueryEscape(query), ds.cfg.APIKeys["https://api.betabotz.eu.org"])
 
 		// Create request with browser-like headers
-		req, err := http.NewRequest("GET", searchURL, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
 		if err != nil {
 			continue
 		}
@@ -1084,12 +1069,12 @@ func (ds *DownloaderSystem) SearchYouTubeByURL(targetURL string) (*SearchResult,
 }
 
 // getYouTubeInfo gets YouTube video information with optimized client
-func (ds *DownloaderSystem) getYouTubeInfo(url string) (*VideoInfo, error) {
+func (ds *DownloaderSystem) getYouTubeInfo(ctx context.Context, url string) (*VideoInfo, error) {
 	apiURL := ds.cfg.API("tio", "/api/youtube/info", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return nil, err
 	}
@@ -1117,12 +1102,12 @@ func (ds *DownloaderSystem) getYouTubeInfo(url string) (*VideoInfo, error) {
 }
 
 // getTikTokInfo gets TikTok video information with optimized client
-func (ds *DownloaderSystem) getTikTokInfo(url string) (*VideoInfo, error) {
+func (ds *DownloaderSystem) getTikTokInfo(ctx context.Context, url string) (*VideoInfo, error) {
 	apiURL := ds.cfg.API("lann", "/api/download/tiktok", map[string]string{
 		"url": url,
 	})
 	
-	resp, err := ds.httpClient.Get(apiURL)
+	resp, err := ds.get(ctx, apiURL)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,312 @@
+// Package testkit drives the bot without a WhatsApp connection, through an
+// in-memory Messenger and a harness feeding messages to the real dispatcher.
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"zumygo/libs"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ libs.Messenger = (*FakeMessenger)(nil)
+
+// BotJID is the account the fake messenger is logged in as
+var BotJID = types.NewJID("6280000000000", types.DefaultUserServer)
+
+// SentMessage is one outgoing message recorded by the fake
+type SentMessage struct {
+	ID      types.MessageID
+	To      types.JID
+	Message *waE2E.Message
+}
+
+// Text returns the visible text of the message: its body, media caption or
+// interactive message text
+func (s SentMessage) Text() string {
+	msg := s.Message
+	if inner := msg.GetViewOnceMessage().GetMessage(); inner != nil {
+		msg = inner
+	}
+	switch {
+	case msg.GetConversation() != "":
+		return msg.GetConversation()
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetText()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetCaption()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetCaption()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetCaption()
+	case msg.GetInteractiveMessage() != nil:
+		return msg.GetInteractiveMessage().GetBody().GetText()
+	case msg.GetButtonsMessage() != nil:
+		return msg.GetButtonsMessage().GetContentText()
+	case msg.GetListMessage() != nil:
+		return msg.GetListMessage().GetDescription()
+	}
+	return ""
+}
+
+// IsReaction reports whether the message is a reaction
+func (s SentMessage) IsReaction() bool {
+	return s.Message.GetReactionMessage() != nil
+}
+
+// FakeMessenger is an in-memory libs.Messenger that records everything the
+// bot sends. Uploaded media can be downloaded back by its direct path.
+type FakeMessenger struct {
+	mutex  sync.Mutex
+	sent   []SentMessage
+	media  map[string][]byte
+	read   []types.MessageID
+	retry  map[types.MessageID]string
+	lids   map[types.JID]types.JID
+	status string
+	nextID int
+
+	// Self and LID are the bot account identities
+	Self types.JID
+	LID  types.JID
+
+	// Groups answers GetGroupInfo; missing groups return an error
+	Groups map[types.JID]*types.GroupInfo
+
+	// SendErr, when set, fails every SendMessage call
+	SendErr error
+}
+
+// NewFakeMessenger creates a fake logged in as BotJID
+func NewFakeMessenger() *FakeMessenger {
+	return &FakeMessenger{
+		Self:   BotJID,
+		Groups: make(map[types.JID]*types.GroupInfo),
+		media:  make(map[string][]byte),
+		retry:  make(map[types.MessageID]string),
+		lids:   make(map[types.JID]types.JID),
+	}
+}
+
+func (f *FakeMessenger) SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.SendErr != nil {
+		return whatsmeow.SendResponse{}, f.SendErr
+	}
+
+	f.nextID++
+	id := types.MessageID(fmt.Sprintf("FAKE%06d", f.nextID))
+	if len(extra) > 0 && extra[0].ID != "" {
+		id = extra[0].ID
+	}
+
+	f.sent = append(f.sent, SentMessage{ID: id, To: to, Message: message})
+	return whatsmeow.SendResponse{ID: id, Timestamp: time.Now(), Sender: f.Self}, nil
+}
+
+func (f *FakeMessenger) BuildReaction(chat, sender types.JID, id types.MessageID, emoji string) *waE2E.Message {
+	return &waE2E.Message{
+		ReactionMessage: &waE2E.ReactionMessage{
+			Key: &waCommon.MessageKey{
+				RemoteJID:   proto.String(chat.String()),
+				FromMe:      proto.Bool(sender.User == f.Self.User),
+				ID:          proto.String(id),
+				Participant: proto.String(sender.String()),
+			},
+			Text:              proto.String(emoji),
+			SenderTimestampMS: proto.Int64(time.Now().UnixMilli()),
+		},
+	}
+}
+
+func (f *FakeMessenger) Upload(ctx context.Context, data []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.nextID++
+	path := fmt.Sprintf("/fake/%s/%d", mediaType, f.nextID)
+	f.media[path] = append([]byte(nil), data...)
+
+	return whatsmeow.UploadResponse{
+		URL:        "https://fake.invalid" + path,
+		DirectPath: path,
+		MediaKey:   []byte("fake-media-key"),
+		FileLength: uint64(len(data)),
+	}, nil
+}
+
+func (f *FakeMessenger) UploadReader(ctx context.Context, data io.Reader, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return whatsmeow.UploadResponse{}, err
+	}
+	return f.Upload(ctx, content, mediaType)
+}
+
+func (f *FakeMessenger) Download(ctx context.Context, media whatsmeow.DownloadableMessage) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	data, ok := f.media[media.GetDirectPath()]
+	if !ok {
+		return nil, whatsmeow.ErrMediaDownloadFailedWith404
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// PutMedia stores media the bot can download by direct path, as if another
+// user had uploaded it
+func (f *FakeMessenger) PutMedia(path string, data []byte) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.media[path] = append([]byte(nil), data...)
+}
+
+func (f *FakeMessenger) RequestMediaRetry(ctx context.Context, info types.MessageInfo, mediaKey []byte) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	path, ok := f.retry[info.ID]
+	if !ok {
+		return "", whatsmeow.ErrMediaNotAvailableOnPhone
+	}
+	return path, nil
+}
+
+// SetMediaRetry makes a media retry for the message return path, as if the
+// sender phone had uploaded it again there
+func (f *FakeMessenger) SetMediaRetry(id types.MessageID, path string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.retry[id] = path
+}
+
+func (f *FakeMessenger) GetGroupInfo(jid types.JID) (*types.GroupInfo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	info, ok := f.Groups[jid]
+	if !ok {
+		return nil, fmt.Errorf("group %s not found", jid)
+	}
+	return info, nil
+}
+
+func (f *FakeMessenger) MarkRead(ids []types.MessageID, timestamp time.Time, chat, sender types.JID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.read = append(f.read, ids...)
+	return nil
+}
+
+func (f *FakeMessenger) SetStatusMessage(status string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.status = status
+	return nil
+}
+
+func (f *FakeMessenger) OwnID() types.JID {
+	return f.Self
+}
+
+func (f *FakeMessenger) OwnLID() types.JID {
+	return f.LID
+}
+
+func (f *FakeMessenger) GetPNForLID(ctx context.Context, lid types.JID) (types.JID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.lids[lid.ToNonAD()], nil
+}
+
+func (f *FakeMessenger) GetLIDForPN(ctx context.Context, pn types.JID) (types.JID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for lid, mapped := range f.lids {
+		if mapped == pn.ToNonAD() {
+			return lid, nil
+		}
+	}
+	return types.EmptyJID, nil
+}
+
+// PutLIDMapping records that lid and pn are the same user, as the LID store
+// learns from incoming messages
+func (f *FakeMessenger) PutLIDMapping(lid, pn types.JID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.lids[lid.ToNonAD()] = pn.ToNonAD()
+}
+
+// Sent returns a copy of every recorded outgoing message
+func (f *FakeMessenger) Sent() []SentMessage {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]SentMessage(nil), f.sent...)
+}
+
+// Texts returns the text of every outgoing message that is not a reaction
+func (f *FakeMessenger) Texts() []string {
+	var texts []string
+	for _, sent := range f.Sent() {
+		if !sent.IsReaction() {
+			texts = append(texts, sent.Text())
+		}
+	}
+	return texts
+}
+
+// Reactions returns the emoji of every outgoing reaction, in order
+func (f *FakeMessenger) Reactions() []string {
+	var emojis []string
+	for _, sent := range f.Sent() {
+		if sent.IsReaction() {
+			emojis = append(emojis, sent.Message.GetReactionMessage().GetText())
+		}
+	}
+	return emojis
+}
+
+// Read returns the IDs of the messages marked as read
+func (f *FakeMessenger) Read() []types.MessageID {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]types.MessageID(nil), f.read...)
+}
+
+// Status returns the last profile about text set by the bot
+func (f *FakeMessenger) Status() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.status
+}
+
+// Reset forgets the recorded outgoing messages and receipts
+func (f *FakeMessenger) Reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.sent = nil
+	f.read = nil
+}
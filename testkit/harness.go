@@ -0,0 +1,128 @@
+package testkit
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+	"zumygo/config"
+	"zumygo/database"
+	"zumygo/handlers"
+	"zumygo/libs"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+var messageCounter int64
+
+// Harness feeds synthetic messages through the real dispatcher, with the
+// commands of every package imported by the test registered as usual
+type Harness struct {
+	Fake   *FakeMessenger
+	Client *libs.IClient
+
+	// Owner is the first configured owner; User is an ordinary sender
+	Owner types.JID
+	User  types.JID
+}
+
+// New creates a harness with default config in public mode and a fresh
+// database in a temporary directory, restoring the globals when t ends
+func New(t testing.TB) *Harness {
+	t.Helper()
+
+	prevConfig, prevDB := config.Config, database.DB
+	t.Cleanup(func() {
+		// Listeners run asynchronously and may still read the globals
+		libs.WaitBackground()
+		config.Config, database.DB = prevConfig, prevDB
+	})
+
+	cfg := config.LoadConfig()
+	cfg.PublicMode = true
+
+	if _, err := database.InitDatabase(filepath.Join(t.TempDir(), "database.json")); err != nil {
+		t.Fatalf("init database: %v", err)
+	}
+
+	fake := NewFakeMessenger()
+	return &Harness{
+		Fake:   fake,
+		Client: libs.NewClient(fake),
+		Owner:  types.NewJID(cfg.Owner[0], types.DefaultUserServer),
+		User:   types.NewJID("6281111111111", types.DefaultUserServer),
+	}
+}
+
+// Send delivers a text message and waits until the pipeline and any command
+// it triggered are done. Commands awaiting an answer block until another
+// goroutine sends it.
+func (h *Harness) Send(chat, sender types.JID, text string) *libs.IMessage {
+	return h.Event(NewMessage(chat, sender, &waE2E.Message{Conversation: proto.String(text)}))
+}
+
+// Private sends a text message in the private chat of sender
+func (h *Harness) Private(sender types.JID, text string) *libs.IMessage {
+	return h.Send(sender, sender, text)
+}
+
+// Event delivers a prepared message event and waits for the work it started
+// in the background, such as listeners and anti-delete reposts
+func (h *Harness) Event(evt *events.Message) *libs.IMessage {
+	m := handlers.ProcessEvent(h.Client, evt)
+	libs.WaitBackground()
+	return m
+}
+
+// Replies returns the text of every non-reaction message sent so far
+func (h *Harness) Replies() []string {
+	return h.Fake.Texts()
+}
+
+// LastReply returns the text of the last non-reaction message, if any
+func (h *Harness) LastReply() string {
+	replies := h.Replies()
+	if len(replies) == 0 {
+		return ""
+	}
+	return replies[len(replies)-1]
+}
+
+// AddGroup registers a group with its admins and members in the fake
+func (h *Harness) AddGroup(group types.JID, admins []types.JID, members ...types.JID) {
+	info := &types.GroupInfo{JID: group}
+	for _, jid := range admins {
+		info.Participants = append(info.Participants, types.GroupParticipant{JID: jid, IsAdmin: true})
+	}
+	for _, jid := range members {
+		info.Participants = append(info.Participants, types.GroupParticipant{JID: jid})
+	}
+
+	h.Fake.mutex.Lock()
+	h.Fake.Groups[group] = info
+	h.Fake.mutex.Unlock()
+	libs.InvalidateGroup(group)
+}
+
+// NewMessage builds an incoming message event from sender in chat
+func NewMessage(chat, sender types.JID, message *waE2E.Message) *events.Message {
+	id := atomic.AddInt64(&messageCounter, 1)
+	return &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:    chat,
+				Sender:  sender,
+				IsGroup: chat.Server == types.GroupServer,
+			},
+			ID:        types.MessageID(fmt.Sprintf("TEST%06d", id)),
+			Type:      "text",
+			PushName:  "Tester",
+			Timestamp: time.Now(),
+		},
+		Message: message,
+	}
+}
@@ -5,13 +5,10 @@ import (
 	"fmt"
 	"zumygo/handlers"
 	"zumygo/helpers"
-	"zumygo/systems"
-	"zumygo/database"
-	"zumygo/config"
+	"zumygo/libs"
+	"zumygo/phone"
 	"os"
 	"os/signal"
-	"regexp"
-	"strings"
 	"syscall"
 	"time"
 
@@ -19,6 +16,7 @@ import (
 	_ "zumygo/commands/owner"     // Import owner commands
 	_ "zumygo/commands/Auto"      // Import auto commands
 	_ "zumygo/commands/downloader" // Import downloader commands
+	_ "zumygo/commands/group"     // Import group commands
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/mdp/qrterminal"
@@ -27,35 +25,14 @@ import (
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	"go.mau.fi/whatsmeow/types"
-	"go.mau.fi/whatsmeow/types/events"
 	waLog "go.mau.fi/whatsmeow/util/log"
 	"google.golang.org/protobuf/proto"
-	waproto "go.mau.fi/whatsmeow/binary/proto"
 )
 
 var (
 	clientLogger helpers.Logger
 )
 
-// CommandMessage represents a command message
-type CommandMessage struct {
-	ID        string
-	From      string
-	Chat      string
-	Text      string
-	Command   string
-	Args      []string
-	IsGroup   bool
-	IsOwner   bool
-	IsAdmin   bool
-	IsPremium bool
-	User      *database.User
-	ChatData  *database.Chat
-	Reply     func(string) error
-	React     func(string) error
-	Delete    func() error
-}
-
 func init() {
 	store.DeviceProps.PlatformType = waCompanionReg.DeviceProps_EDGE.Enum()
 	store.DeviceProps.Os = proto.String("Linux")
@@ -83,8 +60,6 @@ func StartClient() {
 
 	// Get global systems
 	cfg := GetGlobalConfig()
-	db := GetGlobalDatabase()
-	downloaderSystem := GetGlobalDownloaderSystem()
 	
 	// Validate configuration
 	if len(cfg.Owner) == 0 {
@@ -125,7 +100,12 @@ func StartClient() {
 		pairingNumber := cfg.PairingNumber
 
 		if pairingNumber != "" {
-			pairingNumber = regexp.MustCompile(`\D+`).ReplaceAllString(pairingNumber, "")
+			normalized, err := phone.NormalizeWithCountry(pairingNumber, cfg.DefaultCountry)
+			if err != nil {
+				clientLogger.Error(fmt.Sprintf("Invalid pairing number %q: %v", pairingNumber, err))
+				os.Exit(1)
+			}
+			pairingNumber = normalized
 
 			if err := connectWithRetry(conn, 3); err != nil {
 				clientLogger.Error("Failed to connect for pairing: " + err.Error())
@@ -164,11 +144,11 @@ func StartClient() {
 		clientLogger.Info("Connected to WhatsApp successfully")
 	}
 
-	// Bio system is auto-managed via Before hook
-	clientLogger.Info("Bio system auto-managed via Before hook")
+	// Bio system is auto-managed via event listener
+	clientLogger.Info("Bio system auto-managed via event listener")
 
-	// Set up enhanced message handler
-	setupEnhancedMessageHandler(conn, cfg, db, downloaderSystem)
+	// Messages are dispatched through the handlers middleware pipeline
+	clientLogger.Info("Message pipeline ready")
 
 	// Listen to Ctrl+C (you can also do something else that prevents the program from exiting)
 	c := make(chan os.Signal, 1)
@@ -177,6 +157,9 @@ func StartClient() {
 
 	clientLogger.Info("Shutting down gracefully...")
 	
+	// Cancel commands still in flight
+	libs.Shutdown()
+	
 	// Bio system is auto-managed, no need to stop
 	clientLogger.Info("Bio system auto-managed, no cleanup needed")
 	
@@ -189,153 +172,3 @@ func StartClient() {
 	
 	conn.Disconnect()
 }
-
-
-
-// setupEnhancedMessageHandler sets up message handling
-func setupEnhancedMessageHandler(conn *whatsmeow.Client, cfg *config.BotConfig, db *database.Database, downloaderSystem *systems.DownloaderSystem) {
-	conn.AddEventHandler(func(evt interface{}) {
-		switch v := evt.(type) {
-		case *events.Message:
-			handleEnhancedMessage(v, conn, cfg, db, downloaderSystem)
-		}
-	})
-}
-
-// handleEnhancedMessage handles incoming messages
-func handleEnhancedMessage(evt *events.Message, conn *whatsmeow.Client, cfg *config.BotConfig, db *database.Database, downloaderSystem *systems.DownloaderSystem) {
-	// Skip if no message content
-	if evt.Message == nil {
-		return
-	}
-	
-	// Get message text
-	var messageText string
-	if evt.Message.Conversation != nil {
-		messageText = *evt.Message.Conversation
-	} else if evt.Message.ExtendedTextMessage != nil && evt.Message.ExtendedTextMessage.Text != nil {
-		messageText = *evt.Message.ExtendedTextMessage.Text
-	}
-	
-	if messageText == "" {
-		return
-	}
-	
-	// Update database stats
-	db.IncrementMessages()
-	
-	// Update performance metrics
-	if monitor := GetGlobalPerformanceMonitor(); monitor != nil {
-		monitor.IncrementMessageCount()
-	}
-	
-	// Get or create user
-	user := db.GetUser(evt.Info.Sender.String())
-	chat := db.GetChat(evt.Info.Chat.String())
-	
-	// Update chat activity
-	chat.LastActivity = time.Now().Unix()
-	chat.MessageCount++
-	
-
-	
-	// Check if message starts with prefix
-	if !strings.HasPrefix(messageText, cfg.Prefix) {
-		return
-	}
-	
-	// Parse command
-	parts := strings.Fields(messageText[len(cfg.Prefix):])
-	if len(parts) == 0 {
-		return
-	}
-	
-	command := strings.ToLower(parts[0])
-	args := parts[1:]
-	
-	// Check permissions
-	isOwner := cfg.IsOwner(evt.Info.Sender.User)
-	isAdmin := cfg.IsMod(evt.Info.Sender.User) || isOwner
-	isPremium := cfg.IsPrem(evt.Info.Sender.User) || user.Premium || isOwner
-	isGroup := evt.Info.Chat.Server == "g.us"
-	
-	// Create command message
-	cmdMsg := &CommandMessage{
-		ID:        evt.Info.ID,
-		From:      evt.Info.Sender.String(),
-		Chat:      evt.Info.Chat.String(),
-		Text:      messageText,
-		Command:   command,
-		Args:      args,
-		IsGroup:   isGroup,
-		IsOwner:   isOwner,
-		IsAdmin:   isAdmin,
-		IsPremium: isPremium,
-		User:      user,
-		ChatData:  chat,
-		Reply: func(text string) error {
-			_, err := conn.SendMessage(context.Background(), evt.Info.Chat, &waproto.Message{
-				Conversation: &text,
-			})
-			if err != nil {
-				return err
-			}
-			return nil
-		},
-		React: func(emoji string) error {
-			_, err := conn.SendMessage(context.Background(), evt.Info.Chat, &waproto.Message{
-				ReactionMessage: &waproto.ReactionMessage{
-					Key: &waproto.MessageKey{
-						RemoteJID: proto.String(evt.Info.Chat.String()),
-						FromMe:    proto.Bool(false),
-						ID:        proto.String(evt.Info.ID),
-					},
-					Text: proto.String(emoji),
-				},
-			})
-			if err != nil {
-				return err
-			}
-			return nil
-		},
-		Delete: func() error {
-			_, err := conn.SendMessage(context.Background(), evt.Info.Chat, &waproto.Message{
-				ProtocolMessage: &waproto.ProtocolMessage{
-					Type: waproto.ProtocolMessage_REVOKE.Enum(),
-					Key: &waproto.MessageKey{
-						RemoteJID: proto.String(evt.Info.Chat.String()),
-						FromMe:    proto.Bool(false),
-						ID:        proto.String(evt.Info.ID),
-					},
-				},
-			})
-			if err != nil {
-				return err
-			}
-			return nil
-		},
-	}
-	
-	// Handle built-in commands
-			handleBuiltinCommands(cmdMsg, cfg, db, downloaderSystem)
-	
-	// Update command statistics
-	db.IncrementCommand(command)
-	
-	// Update performance metrics
-	if monitor := GetGlobalPerformanceMonitor(); monitor != nil {
-		monitor.IncrementCommandCount()
-	}
-}
-
-// handleBuiltinCommands handles built-in commands
-func handleBuiltinCommands(msg *CommandMessage, cfg *config.BotConfig, db *database.Database, downloaderSystem *systems.DownloaderSystem) {
-	switch msg.Command {
-
-
-
-
-
-
-	}
-}